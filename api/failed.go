@@ -0,0 +1,70 @@
+package api
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/figment-networks/indexing-engine/structs"
+
+	"github.com/figment-networks/ni-cosmoslib/client/cosmosgrpc"
+)
+
+// TxLogError returns the error of the failed transaction. Raw logs of old cosmos-sdk versions
+// are JSON encoded errors, later ones are plain messages with the code in the response.
+func TxLogError(resp *types.TxResponse) (txErr cosmosgrpc.TxLogError) {
+	if err := json.Unmarshal([]byte(resp.RawLog), &txErr); err != nil || txErr.Message == "" {
+		txErr = cosmosgrpc.TxLogError{Message: resp.RawLog}
+	}
+	if resp.Codespace != "" {
+		txErr.Codespace = resp.Codespace
+	}
+	if resp.Code != 0 {
+		txErr.Code = float64(resp.Code)
+	}
+	return txErr
+}
+
+// MarkFailed marks subevents of the message of a failed transaction. Funds of failed transactions
+// don't move (only the fee is charged), so amounts of senders and recipients are moved to the
+// "attempted" amounts, transfers are removed and the error is set.
+func MarkFailed(tev *structs.TransactionEvent, txErr cosmosgrpc.TxLogError) {
+	for i := range tev.Sub {
+		markFailed(&tev.Sub[i], txErr)
+	}
+}
+
+func markFailed(se *structs.SubsetEvent, txErr cosmosgrpc.TxLogError) {
+	se.Error = &structs.SubsetEventError{Message: txErr.Message}
+	if se.Additional == nil {
+		se.Additional = make(map[string][]string)
+	}
+	se.Additional["error_code"] = []string{strconv.FormatFloat(txErr.Code, 'f', -1, 64)}
+	se.Additional["error_codespace"] = []string{txErr.Codespace}
+
+	var attempted []structs.TransactionAmount
+	for i, s := range se.Sender {
+		attempted = append(attempted, s.Amounts...)
+		se.Sender[i].Amounts = nil
+	}
+	for i := range se.Recipient {
+		se.Recipient[i].Amounts = nil
+	}
+	if len(attempted) > 0 {
+		if se.Amount == nil {
+			se.Amount = make(map[string]structs.TransactionAmount)
+		}
+		for i, am := range attempted {
+			k := "attempted"
+			if i > 0 {
+				k += "_" + strconv.Itoa(i)
+			}
+			se.Amount[k] = am
+		}
+	}
+	se.Transfers = nil
+
+	for i := range se.Sub {
+		markFailed(&se.Sub[i], txErr)
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"strconv"
+
+	codec_types "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/figment-networks/indexing-engine/structs"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
+
+	"github.com/figment-networks/ni-cosmoslib/api/mapper"
+)
+
+// AddSubEventAt is AddSubEvent for the message of a transaction at the height, the upgrade schedule
+// decides which messages exist at the height (ie gov v1 messages only from cosmos-sdk v0.46)
+func AddSubEventAt(tev *structs.TransactionEvent, m *codec_types.Any, lg types.ABCIMessageLog, ma *mapper.Mapper, height uint64, schedule util.UpgradeSchedule) (err error) {
+	return DefaultRegistry.AddSubEventAt(tev, m, lg, ma, height, schedule)
+}
+
+// MessageLog returns the log of the message at msgIndex of the transaction at the height.
+// From cosmos-sdk v0.50 logs are empty and the message log is built from the transaction
+// events marked with the msg_index attribute.
+func MessageLog(resp *types.TxResponse, msgIndex int, height uint64, schedule util.UpgradeSchedule) (lg types.ABCIMessageLog) {
+	u := schedule.At(height)
+	if u.SDKVersion == "" || !u.SDKAtLeast("v0.50") {
+		for _, l := range resp.Logs {
+			if int(l.MsgIndex) == msgIndex {
+				return l
+			}
+		}
+		if u.SDKVersion != "" {
+			return types.ABCIMessageLog{MsgIndex: uint32(msgIndex)}
+		}
+	}
+
+	lg.MsgIndex = uint32(msgIndex)
+	index := strconv.Itoa(msgIndex)
+	// same as in the logs, attributes of events with the same type are merged
+	byType := make(map[string]int)
+	for _, ev := range resp.Events {
+		var (
+			inMsg bool
+			attrs []types.Attribute
+		)
+		for _, attr := range ev.Attributes {
+			if string(attr.Key) == "msg_index" {
+				inMsg = string(attr.Value) == index
+				continue
+			}
+			attrs = append(attrs, types.Attribute{Key: string(attr.Key), Value: string(attr.Value)})
+		}
+		if !inMsg {
+			continue
+		}
+		if i, ok := byType[ev.Type]; ok {
+			lg.Events[i].Attributes = append(lg.Events[i].Attributes, attrs...)
+			continue
+		}
+		byType[ev.Type] = len(lg.Events)
+		lg.Events = append(lg.Events, types.StringEvent{Type: ev.Type, Attributes: attrs})
+	}
+	return lg
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	codec_types "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/figment-networks/indexing-engine/structs"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
+)
+
+var testSchedule = util.UpgradeSchedule{
+	{Height: 100, Name: "v5", SDKVersion: "v0.42.11"},
+	{Height: 200, Name: "v7", SDKVersion: "v0.45.1"},
+	{Height: 300, Name: "v10", SDKVersion: "v0.46.13"},
+	{Height: 400, Name: "v15", SDKVersion: "v0.50.1"},
+}
+
+func TestAddSubEventAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		typeURL  string
+		height   uint64
+		schedule util.UpgradeSchedule
+		wantErr  error
+	}{
+		{name: "unjail_v0.42", typeURL: "/cosmos.slashing.v1beta1.MsgUnjail", height: 150, schedule: testSchedule},
+		{name: "gov_v1_v0.45", typeURL: "/cosmos.gov.v1.MsgVote", height: 250, schedule: testSchedule, wantErr: util.ErrUnknownMessageType},
+		{name: "gov_v1_v0.46", typeURL: "/cosmos.gov.v1.MsgVote", height: 350, schedule: testSchedule},
+		{name: "gov_v1_no_schedule", typeURL: "/cosmos.gov.v1.MsgVote", height: 250},
+		{name: "group_v0.45", typeURL: "/cosmos.group.v1.MsgCreateGroup", height: 250, schedule: testSchedule, wantErr: util.ErrUnknownMessageType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tev := &structs.TransactionEvent{}
+			err := AddSubEventAt(tev, &codec_types.Any{TypeUrl: tt.typeURL}, types.ABCIMessageLog{}, nil, tt.height, tt.schedule)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AddSubEventAt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMessageLog(t *testing.T) {
+	resp := &types.TxResponse{
+		Logs: types.ABCIMessageLogs{{MsgIndex: 0, Events: types.StringEvents{{Type: "from_logs"}}}},
+		Events: types.Events{
+			types.NewEvent("tx", types.NewAttribute("fee", "10uatom")),
+			types.NewEvent("transfer", types.NewAttribute("amount", "1uatom"), types.NewAttribute("msg_index", "0")),
+			types.NewEvent("transfer", types.NewAttribute("amount", "2uatom"), types.NewAttribute("msg_index", "1")),
+			types.NewEvent("transfer", types.NewAttribute("amount", "3uatom"), types.NewAttribute("msg_index", "1")),
+		}.ToABCIEvents(),
+	}
+
+	if lg := MessageLog(resp, 0, 150, testSchedule); len(lg.Events) != 1 || lg.Events[0].Type != "from_logs" {
+		t.Errorf("MessageLog() v0.42 unexpected log %+v", lg)
+	}
+
+	lg := MessageLog(resp, 1, 450, testSchedule)
+	if lg.MsgIndex != 1 || len(lg.Events) != 1 || lg.Events[0].Type != "transfer" {
+		t.Fatalf("MessageLog() v0.50 unexpected log %+v", lg)
+	}
+	if attrs := lg.Events[0].Attributes; len(attrs) != 2 || attrs[0].Value != "2uatom" || attrs[1].Value != "3uatom" {
+		t.Errorf("MessageLog() v0.50 unexpected attributes %+v", attrs)
+	}
+}
@@ -0,0 +1,162 @@
+package mapper
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	codec_types "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	authz "github.com/cosmos/cosmos-sdk/x/authz"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	crisis "github.com/cosmos/cosmos-sdk/x/crisis/types"
+	distribution "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	evidence "github.com/cosmos/cosmos-sdk/x/evidence/types"
+	feegrant "github.com/cosmos/cosmos-sdk/x/feegrant"
+	gov "github.com/cosmos/cosmos-sdk/x/gov/types"
+	slashing "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/figment-networks/indexing-engine/structs"
+)
+
+var pubKeyRegistry = newPubKeyRegistry()
+
+func newPubKeyRegistry() codec_types.InterfaceRegistry {
+	r := codec_types.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(r)
+	return r
+}
+
+var msgRegistry = newMsgRegistry()
+
+// newMsgRegistry registers cosmos-sdk messages to get signers of the transactions
+func newMsgRegistry() codec_types.InterfaceRegistry {
+	r := codec_types.NewInterfaceRegistry()
+	types.RegisterInterfaces(r)
+	authz.RegisterInterfaces(r)
+	bank.RegisterInterfaces(r)
+	crisis.RegisterInterfaces(r)
+	distribution.RegisterInterfaces(r)
+	evidence.RegisterInterfaces(r)
+	feegrant.RegisterInterfaces(r)
+	gov.RegisterInterfaces(r)
+	slashing.RegisterInterfaces(r)
+	staking.RegisterInterfaces(r)
+	return r
+}
+
+// AuthInfoToSub transforms tx.AuthInfo of the transaction to the "fee" SubsetEvent. Fee is transferred
+// from the payer to the fee collector, the payer is the feegrant granter from the use_feegrant event
+// of the transaction events (TxResponse.Events), the fee payer or the first signer of the first message
+// of msgs (the transaction body messages). Signers and public keys are index-aligned with sequences,
+// they are empty for signers without the public key or with keys of unknown types.
+func (mapper *Mapper) AuthInfoToSub(authInfo *tx.AuthInfo, msgs []*codec_types.Any, events types.StringEvents) (se structs.SubsetEvent, err error) {
+	se = structs.SubsetEvent{
+		Type:   []string{"fee"},
+		Module: "auth",
+		Node:   make(map[string][]structs.Account),
+	}
+
+	fee := authInfo.GetFee()
+	se.Additional = map[string][]string{"gas_limit": {strconv.FormatUint(fee.GetGasLimit(), 10)}}
+
+	for _, si := range authInfo.GetSignerInfos() {
+		se.Additional["sequence"] = append(se.Additional["sequence"], strconv.FormatUint(si.Sequence, 10))
+		var (
+			pk        cryptotypes.PubKey
+			addr, key string
+		)
+		// keys of types unknown to cosmos-sdk (ie ethermint ethsecp256k1) are left empty
+		if si.PublicKey != nil && pubKeyRegistry.UnpackAny(si.PublicKey, &pk) == nil {
+			if addr, err = bech32.ConvertAndEncode(mapper.bech32Prefix(), pk.Address()); err != nil {
+				return se, fmt.Errorf("error encoding signer address: %w", err)
+			}
+			key = base64.StdEncoding.EncodeToString(pk.Bytes())
+		}
+		se.Node["signer"] = append(se.Node["signer"], structs.Account{ID: addr})
+		se.Additional["public_key"] = append(se.Additional["public_key"], key)
+	}
+
+	granter := fee.GetGranter()
+	for _, ev := range events {
+		if ev.Type != "use_feegrant" {
+			continue
+		}
+		for _, attr := range ev.Attributes {
+			if attr.Key == "granter" {
+				granter = attr.Value
+			}
+		}
+	}
+
+	var payer string
+	switch {
+	case granter != "":
+		payer = granter
+		se.Node["granter"] = []structs.Account{{ID: granter}}
+	case fee.GetPayer() != "":
+		payer = fee.GetPayer()
+	default:
+		payer, err = mapper.firstSigner(msgs)
+		if err != nil {
+			return se, err
+		}
+		// the first signer info is the one of the first message signer
+		if payer == "" && len(se.Node["signer"]) > 0 {
+			payer = se.Node["signer"][0].ID
+		}
+	}
+	if payer != "" {
+		se.Node["payer"] = []structs.Account{{ID: payer}}
+	}
+
+	if fee.GetAmount().IsZero() {
+		return se, nil
+	}
+	CoinsToAmount(&se, "fee", fee.Amount)
+	amounts := CoinsToAmounts(fee.Amount)
+	if payer != "" {
+		se.Sender = []structs.EventTransfer{{Account: structs.Account{ID: payer}, Amounts: amounts}}
+	}
+	if mapper.FeeCollectorAddress != "" {
+		se.Transfers = map[string][]structs.EventTransfer{
+			"fee": {{Account: structs.Account{ID: mapper.FeeCollectorAddress}, Amounts: amounts}},
+		}
+	}
+	return se, nil
+}
+
+// firstSigner returns the first signer of the first message, empty for messages of types unknown to cosmos-sdk
+// and for addresses with prefix other than the one of the cosmos-sdk config (GetSigners panics on them)
+func (mapper *Mapper) firstSigner(msgs []*codec_types.Any) (string, error) {
+	if len(msgs) == 0 {
+		return "", nil
+	}
+	var msg types.Msg
+	if err := msgRegistry.UnpackAny(msgs[0], &msg); err != nil {
+		return "", nil
+	}
+	signers := func() (signers []types.AccAddress) {
+		defer func() { recover() }()
+		return msg.GetSigners()
+	}()
+	if len(signers) == 0 {
+		return "", nil
+	}
+	addr, err := bech32.ConvertAndEncode(mapper.bech32Prefix(), signers[0])
+	if err != nil {
+		return "", fmt.Errorf("error encoding signer address: %w", err)
+	}
+	return addr, nil
+}
+
+func (mapper *Mapper) bech32Prefix() string {
+	if mapper.Bech32Prefix == "" {
+		return "cosmos"
+	}
+	return mapper.Bech32Prefix
+}
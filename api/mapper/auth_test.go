@@ -0,0 +1,135 @@
+package mapper
+
+import (
+	"testing"
+
+	codec_types "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
+)
+
+func TestMapper_AuthInfoToSub(t *testing.T) {
+	p, err := util.BuiltinChainProfile("osmosis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ma, err := NewMapper(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pk := secp256k1.GenPrivKey().PubKey()
+	signer, err := bech32.ConvertAndEncode("osmo", pk.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkAny, err := codec_types.NewAnyWithValue(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		fee         *tx.Fee
+		events      types.StringEvents
+		wantPayer   string
+		wantGranter string
+	}{
+		{
+			name:      "signer_pays",
+			fee:       &tx.Fee{Amount: types.NewCoins(types.NewInt64Coin("uosmo", 2500)), GasLimit: 100000},
+			wantPayer: signer,
+		},
+		{
+			name:      "fee_payer",
+			fee:       &tx.Fee{Amount: types.NewCoins(types.NewInt64Coin("uosmo", 2500)), Payer: "osmo1payer"},
+			wantPayer: "osmo1payer",
+		},
+		{
+			name: "feegrant",
+			fee:  &tx.Fee{Amount: types.NewCoins(types.NewInt64Coin("uosmo", 2500))},
+			events: types.StringEvents{{Type: "use_feegrant", Attributes: []types.Attribute{
+				{Key: "granter", Value: "osmo1granter"}, {Key: "grantee", Value: signer},
+			}}},
+			wantPayer:   "osmo1granter",
+			wantGranter: "osmo1granter",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authInfo := &tx.AuthInfo{SignerInfos: []*tx.SignerInfo{{PublicKey: pkAny, Sequence: 7}}, Fee: tt.fee}
+			se, err := ma.AuthInfoToSub(authInfo, nil, tt.events)
+			if err != nil {
+				t.Fatalf("Mapper.AuthInfoToSub() error = %v", err)
+			}
+			if s := se.Node["signer"]; len(s) != 1 || s[0].ID != signer || se.Additional["sequence"][0] != "7" {
+				t.Errorf("Mapper.AuthInfoToSub() unexpected signers %+v %v", s, se.Additional)
+			}
+			if len(se.Sender) != 1 || se.Sender[0].Account.ID != tt.wantPayer {
+				t.Errorf("Mapper.AuthInfoToSub() sender = %+v, want %s", se.Sender, tt.wantPayer)
+			}
+			if g := se.Node["granter"]; (tt.wantGranter == "" && len(g) != 0) || (tt.wantGranter != "" && (len(g) != 1 || g[0].ID != tt.wantGranter)) {
+				t.Errorf("Mapper.AuthInfoToSub() granter = %+v, want %s", g, tt.wantGranter)
+			}
+			fee := se.Transfers["fee"]
+			if len(fee) != 1 || fee[0].Account.ID != ma.FeeCollectorAddress || fee[0].Amounts[0].Text != "2500" {
+				t.Errorf("Mapper.AuthInfoToSub() unexpected fee transfers %+v", fee)
+			}
+		})
+	}
+}
+
+func TestMapper_AuthInfoToSub_Signers(t *testing.T) {
+	ma := &Mapper{Bech32Prefix: "cosmos"}
+
+	pk := secp256k1.GenPrivKey().PubKey()
+	signer, err := bech32.ConvertAndEncode("cosmos", pk.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkAny, err := codec_types.NewAnyWithValue(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, err := bech32.ConvertAndEncode("cosmos", secp256k1.GenPrivKey().PubKey().Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	send, err := codec_types.NewAnyWithValue(&bank.MsgSend{FromAddress: sender, ToAddress: signer, Amount: types.NewCoins(types.NewInt64Coin("uatom", 1))})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authInfo := &tx.AuthInfo{
+		SignerInfos: []*tx.SignerInfo{
+			{Sequence: 3},
+			{PublicKey: pkAny, Sequence: 7},
+			{PublicKey: &codec_types.Any{TypeUrl: "/ethermint.crypto.v1.ethsecp256k1.PubKey"}, Sequence: 1},
+		},
+		Fee: &tx.Fee{Amount: types.NewCoins(types.NewInt64Coin("uatom", 2500))},
+	}
+	se, err := ma.AuthInfoToSub(authInfo, []*codec_types.Any{send}, nil)
+	if err != nil {
+		t.Fatalf("Mapper.AuthInfoToSub() error = %v", err)
+	}
+
+	s := se.Node["signer"]
+	if len(s) != 3 || s[0].ID != "" || s[1].ID != signer || s[2].ID != "" {
+		t.Errorf("Mapper.AuthInfoToSub() signers not aligned with sequences %+v", s)
+	}
+	if k := se.Additional["public_key"]; len(k) != 3 || k[0] != "" || k[1] == "" || k[2] != "" {
+		t.Errorf("Mapper.AuthInfoToSub() public keys not aligned with sequences %v", k)
+	}
+	if q := se.Additional["sequence"]; len(q) != 3 || q[1] != "7" {
+		t.Errorf("Mapper.AuthInfoToSub() unexpected sequences %v", q)
+	}
+	// the payer is the signer of the first message, not the first known public key
+	if len(se.Sender) != 1 || se.Sender[0].Account.ID != sender || se.Node["payer"][0].ID != sender {
+		t.Errorf("Mapper.AuthInfoToSub() sender = %+v, want %s", se.Sender, sender)
+	}
+}
@@ -0,0 +1,137 @@
+package mapper
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/figment-networks/indexing-engine/structs"
+	"github.com/figment-networks/ni-cosmoslib/util"
+
+	codec_types "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// authorizationToSub adds decoded authz authorization to SubsetEvent
+func authorizationToSub(se *structs.SubsetEvent, auth *codec_types.Any) error {
+	if auth == nil {
+		return nil
+	}
+	se.Additional["authorization_type_url"] = []string{auth.TypeUrl}
+
+	switch auth.TypeUrl {
+	case "/cosmos.authz.v1beta1.GenericAuthorization":
+		a := &authz.GenericAuthorization{}
+		if err := proto.Unmarshal(auth.Value, a); err != nil {
+			return util.Errorf(util.ErrMalformedMessage, "Not a generic_authorization type: %w", err)
+		}
+		se.Additional["authorization_msg"] = []string{a.Msg}
+	case "/cosmos.bank.v1beta1.SendAuthorization":
+		a := &bank.SendAuthorization{}
+		if err := proto.Unmarshal(auth.Value, a); err != nil {
+			return util.Errorf(util.ErrMalformedMessage, "Not a send_authorization type: %w", err)
+		}
+		se.Additional["authorization_msg"] = []string{"/cosmos.bank.v1beta1.MsgSend"}
+		CoinsToAmount(se, "spend_limit", a.SpendLimit)
+	case "/cosmos.staking.v1beta1.StakeAuthorization":
+		a := &staking.StakeAuthorization{}
+		if err := proto.Unmarshal(auth.Value, a); err != nil {
+			return util.Errorf(util.ErrMalformedMessage, "Not a stake_authorization type: %w", err)
+		}
+		se.Additional["authorization_type"] = []string{a.AuthorizationType.String()}
+		if a.MaxTokens != nil {
+			CoinsToAmount(se, "max_tokens", types.Coins{*a.MaxTokens})
+		}
+		if allow := a.GetAllowList(); allow != nil {
+			se.Additional["allow_list"] = allow.Address
+		}
+		if deny := a.GetDenyList(); deny != nil {
+			se.Additional["deny_list"] = deny.Address
+		}
+	default:
+		// Encode unknown authorizations that can contain null bytes.
+		value, err := util.EncodeToB64(auth.Value, "authorization")
+		if err != nil {
+			return err
+		}
+		se.Additional["grant_authorization"] = []string{value}
+	}
+
+	return nil
+}
+
+// allowanceToSub adds decoded feegrant allowance to SubsetEvent
+func allowanceToSub(se *structs.SubsetEvent, allowance *codec_types.Any) error {
+	if allowance == nil {
+		return nil
+	}
+	se.Additional["allowance_type_url"] = append(se.Additional["allowance_type_url"], allowance.TypeUrl)
+
+	switch allowance.TypeUrl {
+	case "/cosmos.feegrant.v1beta1.BasicAllowance":
+		a := &feegrant.BasicAllowance{}
+		if err := proto.Unmarshal(allowance.Value, a); err != nil {
+			return util.Errorf(util.ErrMalformedMessage, "Not a basic_allowance type: %w", err)
+		}
+		basicAllowanceToSub(se, a)
+	case "/cosmos.feegrant.v1beta1.PeriodicAllowance":
+		a := &feegrant.PeriodicAllowance{}
+		if err := proto.Unmarshal(allowance.Value, a); err != nil {
+			return util.Errorf(util.ErrMalformedMessage, "Not a periodic_allowance type: %w", err)
+		}
+		basicAllowanceToSub(se, &a.Basic)
+		se.Additional["period"] = []string{a.Period.String()}
+		se.Additional["period_seconds"] = []string{strconv.FormatFloat(a.Period.Seconds(), 'f', -1, 64)}
+		se.Additional["period_reset"] = []string{a.PeriodReset.Format(time.RFC3339)}
+		CoinsToAmount(se, "period_spend_limit", a.PeriodSpendLimit)
+		CoinsToAmount(se, "period_can_spend", a.PeriodCanSpend)
+	case "/cosmos.feegrant.v1beta1.AllowedMsgAllowance":
+		a := &feegrant.AllowedMsgAllowance{}
+		if err := proto.Unmarshal(allowance.Value, a); err != nil {
+			return util.Errorf(util.ErrMalformedMessage, "Not a allowed_msg_allowance type: %w", err)
+		}
+		se.Additional["allowed_messages"] = a.AllowedMessages
+		return allowanceToSub(se, a.Allowance)
+	default:
+		// Encode unknown allowances that can contain null bytes.
+		value, err := util.EncodeToB64(allowance.Value, "allowance")
+		if err != nil {
+			return err
+		}
+		se.Additional["allowance"] = []string{value}
+	}
+
+	return nil
+}
+
+func basicAllowanceToSub(se *structs.SubsetEvent, a *feegrant.BasicAllowance) {
+	CoinsToAmount(se, "spend_limit", a.SpendLimit)
+	if a.Expiration != nil {
+		se.Additional["expiration"] = []string{a.Expiration.Format(time.RFC3339)}
+	}
+}
+
+// CoinsToAmount adds coins to SubsetEvent Amount under key, key_1, key_2...
+func CoinsToAmount(se *structs.SubsetEvent, key string, coins types.Coins) {
+	if len(coins) == 0 {
+		return
+	}
+	if se.Amount == nil {
+		se.Amount = map[string]structs.TransactionAmount{}
+	}
+	for i, coin := range coins {
+		k := key
+		if i > 0 {
+			k += "_" + strconv.Itoa(i)
+		}
+		se.Amount[k] = structs.TransactionAmount{
+			Currency: coin.Denom,
+			Numeric:  coin.Amount.BigInt(),
+			Text:     coin.Amount.String(),
+		}
+	}
+}
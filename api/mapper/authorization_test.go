@@ -0,0 +1,103 @@
+package mapper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	codec_types "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+func TestMapper_AuthzGrantToSub_StakeAuthorization(t *testing.T) {
+	maxTokens := types.NewInt64Coin("uatom", 1000)
+	auth, err := codec_types.NewAnyWithValue(&staking.StakeAuthorization{
+		MaxTokens:         &maxTokens,
+		Validators:        &staking.StakeAuthorization_AllowList{AllowList: &staking.StakeAuthorization_Validators{Address: []string{"cosmosvaloper1a", "cosmosvaloper1b"}}},
+		AuthorizationType: staking.AuthorizationType_AUTHORIZATION_TYPE_DELEGATE,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := (&authz.MsgGrant{
+		Granter: "cosmos1granter",
+		Grantee: "cosmos1grantee",
+		Grant:   authz.Grant{Authorization: auth, Expiration: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	se, err := (&Mapper{}).AuthzGrantToSub(msg)
+	if err != nil {
+		t.Fatalf("Mapper.AuthzGrantToSub() error = %v", err)
+	}
+
+	if got := se.Amount["max_tokens"]; got.Currency != "uatom" || got.Text != "1000" {
+		t.Errorf("unexpected max_tokens %+v", got)
+	}
+	if got := se.Additional["allow_list"]; !reflect.DeepEqual(got, []string{"cosmosvaloper1a", "cosmosvaloper1b"}) {
+		t.Errorf("unexpected allow_list %v", got)
+	}
+	if got := se.Additional["authorization_type"]; !reflect.DeepEqual(got, []string{"AUTHORIZATION_TYPE_DELEGATE"}) {
+		t.Errorf("unexpected authorization_type %v", got)
+	}
+	if _, ok := se.Additional["grant_authorization"]; ok {
+		t.Errorf("known authorization should not be encoded")
+	}
+}
+
+func TestMapper_FeegrantGrantAllowance_AllowedMsgAllowance(t *testing.T) {
+	expiration := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	periodic, err := codec_types.NewAnyWithValue(&feegrant.PeriodicAllowance{
+		Basic: feegrant.BasicAllowance{
+			SpendLimit: types.NewCoins(types.NewInt64Coin("uatom", 500)),
+			Expiration: &expiration,
+		},
+		Period:           time.Hour,
+		PeriodSpendLimit: types.NewCoins(types.NewInt64Coin("uatom", 50)),
+		PeriodCanSpend:   types.NewCoins(types.NewInt64Coin("uatom", 20)),
+		PeriodReset:      expiration,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowed, err := codec_types.NewAnyWithValue(&feegrant.AllowedMsgAllowance{
+		Allowance:       periodic,
+		AllowedMessages: []string{"/cosmos.gov.v1beta1.MsgVote"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := (&feegrant.MsgGrantAllowance{Granter: "cosmos1granter", Grantee: "cosmos1grantee", Allowance: allowed}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	se, err := (&Mapper{}).FeegrantGrantAllowance(msg)
+	if err != nil {
+		t.Fatalf("Mapper.FeegrantGrantAllowance() error = %v", err)
+	}
+
+	for key, want := range map[string]string{"spend_limit": "500", "period_spend_limit": "50", "period_can_spend": "20"} {
+		if got := se.Amount[key]; got.Text != want || got.Currency != "uatom" {
+			t.Errorf("unexpected %s %+v", key, got)
+		}
+	}
+	wantAdditional := map[string][]string{
+		"granter":            {"cosmos1granter"},
+		"grantee":            {"cosmos1grantee"},
+		"allowance_type_url": {"/cosmos.feegrant.v1beta1.AllowedMsgAllowance", "/cosmos.feegrant.v1beta1.PeriodicAllowance"},
+		"allowed_messages":   {"/cosmos.gov.v1beta1.MsgVote"},
+		"expiration":         {"2023-01-01T00:00:00Z"},
+		"period":             {"1h0m0s"},
+		"period_seconds":     {"3600"},
+		"period_reset":       {"2023-01-01T00:00:00Z"},
+	}
+	if !reflect.DeepEqual(se.Additional, wantAdditional) {
+		t.Errorf("Mapper.FeegrantGrantAllowance() additional = %v, want %v", se.Additional, wantAdditional)
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"fmt"
 
 	"github.com/figment-networks/indexing-engine/structs"
+	"github.com/figment-networks/ni-cosmoslib/util"
 
+	codec_types "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/authz"
 	"github.com/gogo/protobuf/proto"
 )
@@ -13,67 +16,218 @@ import (
 func (mapper *Mapper) AuthzGrantToSub(msg []byte) (se structs.SubsetEvent, err error) {
 	m := &authz.MsgGrant{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a grant type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a grant type: %w", err)
 	}
 
-	return structs.SubsetEvent{
+	se = structs.SubsetEvent{
 		Type:   []string{"grant"},
 		Module: "authz",
+		Node: map[string][]structs.Account{
+			"granter": {{ID: m.Granter}},
+			"grantee": {{ID: m.Grantee}},
+		},
 		Additional: map[string][]string{
-			"granter":             {m.Granter},
-			"grantee":             {m.Grantee},
-			"grant_expiration":    {m.Grant.Expiration.String()},
-			"grant_authorization": {string(m.Grant.Authorization.Value)},
+			"granter":          {m.Granter},
+			"grantee":          {m.Grantee},
+			"grant_expiration": {m.Grant.Expiration.String()},
 		},
-	}, nil
+	}
+
+	err = authorizationToSub(&se, m.Grant.Authorization)
+	return se, err
 }
 
 // AuthzExecResponseToSub transforms authz.MsgExecResponse sdk messages to SubsetEvent
 func (mapper *Mapper) AuthzExecResponseToSub(msg []byte) (se structs.SubsetEvent, err error) {
 	m := &authz.MsgExecResponse{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a exec_response type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a exec_response type: %w", err)
 	}
 
 	se = structs.SubsetEvent{
-		Type:   []string{"exec_response"},
-		Module: "authz",
+		Type:       []string{"exec_response"},
+		Module:     "authz",
+		Additional: map[string][]string{},
 	}
 
 	for _, result := range m.Results {
-		se.Additional["results"] = append(se.Additional["results"], string(result))
+		// Encode fields that can contain null bytes.
+		r, err := util.EncodeToB64(result, "result")
+		if err != nil {
+			return se, err
+		}
+		se.Additional["results"] = append(se.Additional["results"], r)
 	}
 
 	return se, nil
 }
 
-// AuthzExecToSub transforms authz.MsgExec sdk messages to SubsetEvent
-func (mapper *Mapper) AuthzExecToSub(msg []byte) (se structs.SubsetEvent, err error) {
+// SubMapper maps messages carried inside other messages (ie authz.MsgExec)
+type SubMapper func(m *codec_types.Any, lg types.ABCIMessageLog) (structs.SubsetEvent, error)
+
+// AuthzExecToSub transforms authz.MsgExec sdk messages to SubsetEvent, every executed message is mapped by sub
+// and added to Sub attributed to its granter
+func (mapper *Mapper) AuthzExecToSub(msg []byte, lg types.ABCIMessageLog, sub SubMapper) (se structs.SubsetEvent, err error) {
 	m := &authz.MsgExec{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a exec type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a exec type: %w", err)
 	}
 
 	se = structs.SubsetEvent{
 		Type:   []string{"exec"},
 		Module: "authz",
+		Node: map[string][]structs.Account{
+			"grantee": {{ID: m.Grantee}},
+		},
 		Additional: map[string][]string{
 			"grantee": {m.Grantee},
 		},
 	}
 
-	for _, mg := range m.Msgs {
-		se.Additional["msgs"] = append(se.Additional["msgs"], string(mg.Value))
+	subs := make([]structs.SubsetEvent, len(m.Msgs))
+	subErrs := make([]error, len(m.Msgs))
+	subLogs := []types.ABCIMessageLog{lg}
+	rest := types.ABCIMessageLog{MsgIndex: lg.MsgIndex, Log: lg.Log}
+	if len(m.Msgs) == 1 {
+		subs[0], subErrs[0] = sub(m.Msgs[0], lg)
+	} else {
+		// all the executed messages share a single log, granters are taken from the messages mapped without it
+		granters := make([]string, len(m.Msgs))
+		for i, mg := range m.Msgs {
+			subs[i], subErrs[i] = sub(mg, types.ABCIMessageLog{MsgIndex: lg.MsgIndex})
+			granters[i] = execGranter(subs[i])
+		}
+		subLogs, rest = execLogs(lg, granters)
+		for i, subLg := range subLogs {
+			if len(subLg.Events) > 0 {
+				subs[i], subErrs[i] = sub(m.Msgs[i], subLg)
+			}
+		}
 	}
 
-	return se, nil
+	// transfers of the mapped messages are in their subevents, MsgExec has only the ones not attributed to them
+	for i, subLg := range subLogs {
+		if len(subs[i].Type) == 0 {
+			for _, ev := range subLg.Events {
+				rest.Events = appendStringEvent(rest.Events, ev)
+			}
+		}
+	}
+	if err = produceTransfers(&se, "send", "", rest); err != nil {
+		return se, err
+	}
+
+	granters := map[string]struct{}{}
+	for i, mg := range m.Msgs {
+		se.Additional["msgs_type_url"] = append(se.Additional["msgs_type_url"], mg.TypeUrl)
+
+		ev := subs[i]
+		if subErrs[i] != nil && err == nil {
+			err = fmt.Errorf("problem with exec message %s: %w", mg.TypeUrl, subErrs[i])
+		}
+		if len(ev.Type) == 0 {
+			continue
+		}
+
+		if ev.Node == nil {
+			ev.Node = map[string][]structs.Account{}
+		}
+		ev.Node["grantee"] = []structs.Account{{ID: m.Grantee}}
+		if granter := execGranter(ev); granter != "" {
+			ev.Node["granter"] = []structs.Account{{ID: granter}}
+			if _, ok := granters[granter]; !ok {
+				granters[granter] = struct{}{}
+				se.Node["granter"] = append(se.Node["granter"], structs.Account{ID: granter})
+				se.Additional["granter"] = append(se.Additional["granter"], granter)
+			}
+		}
+		se.Sub = append(se.Sub, ev)
+	}
+
+	return se, err
+}
+
+// execGranterKeys are keys under which mappers put the signer of messages that can be executed by authz
+var execGranterKeys = []string{"delegator", "voter", "depositor", "proposer", "granter"}
+
+// execGranter returns the signer of executed message, which is the account that granted the authorization
+func execGranter(ev structs.SubsetEvent) string {
+	for _, k := range execGranterKeys {
+		if acc, ok := ev.Node[k]; ok && len(acc) > 0 {
+			return acc[0].ID
+		}
+		if acc, ok := ev.Additional[k]; ok && len(acc) > 0 {
+			return acc[0]
+		}
+	}
+	if len(ev.Sender) > 0 {
+		return ev.Sender[0].Account.ID
+	}
+	return ""
+}
+
+// execEventAccounts are attributes of events naming the account the event was emitted for
+var execEventAccounts = []string{"sender", "recipient", "spender", "receiver", "delegator", "depositor", "voter", "withdraw_address", "granter", "burner", "minter"}
+
+// execLogs splits the log of MsgExec among the executed messages of the granters. Events of all the messages
+// are merged in one log, every event is attributed to the granter it names. Events of messages of the same
+// granter can't be told apart, so these are attributed to the first message of the granter. Events naming
+// no granter (ie emitted for module accounts) are returned in the rest of the MsgExec log.
+func execLogs(lg types.ABCIMessageLog, granters []string) (logs []types.ABCIMessageLog, rest types.ABCIMessageLog) {
+	first := map[string]int{}
+	rest = types.ABCIMessageLog{MsgIndex: lg.MsgIndex, Log: lg.Log}
+	logs = make([]types.ABCIMessageLog, len(granters))
+	for i, g := range granters {
+		logs[i] = types.ABCIMessageLog{MsgIndex: lg.MsgIndex, Log: lg.Log}
+		if _, ok := first[g]; !ok && g != "" {
+			first[g] = i
+		}
+	}
+
+	for _, ev := range lg.GetEvents() {
+		for _, inst := range splitStringEvent(ev) {
+			attributed := map[int]bool{}
+			for _, attr := range inst.Attributes {
+				i, ok := first[attr.Value]
+				if !ok || attributed[i] || !isExecEventAccount(attr.Key) {
+					continue
+				}
+				attributed[i] = true
+				logs[i].Events = appendStringEvent(logs[i].Events, inst)
+			}
+			if len(attributed) == 0 {
+				rest.Events = appendStringEvent(rest.Events, inst)
+			}
+		}
+	}
+	return logs, rest
+}
+
+func isExecEventAccount(key string) bool {
+	for _, k := range execEventAccounts {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// appendStringEvent appends attributes of the event to the event of the same type, as events are merged in logs
+func appendStringEvent(events types.StringEvents, ev types.StringEvent) types.StringEvents {
+	for i := range events {
+		if events[i].Type == ev.Type {
+			events[i].Attributes = append(events[i].Attributes, ev.Attributes...)
+			return events
+		}
+	}
+	return append(events, types.StringEvent{Type: ev.Type, Attributes: append([]types.Attribute{}, ev.Attributes...)})
 }
 
 // AuthzGrantResponseToSub transforms authz.MsgGrantResponse sdk messages to SubsetEvent
 func (mapper *Mapper) AuthzGrantResponseToSub(msg []byte) (se structs.SubsetEvent, err error) {
 	m := &authz.MsgGrantResponse{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a grant_response type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a grant_response type: %w", err)
 	}
 
 	return structs.SubsetEvent{
@@ -86,7 +240,7 @@ func (mapper *Mapper) AuthzGrantResponseToSub(msg []byte) (se structs.SubsetEven
 func (mapper *Mapper) AuthzMsgRevokeToSub(msg []byte) (se structs.SubsetEvent, err error) {
 	m := &authz.MsgRevoke{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a revoke type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a revoke type: %w", err)
 	}
 
 	return structs.SubsetEvent{
@@ -104,7 +258,7 @@ func (mapper *Mapper) AuthzMsgRevokeToSub(msg []byte) (se structs.SubsetEvent, e
 func (mapper *Mapper) AuthzMsgRevokeResponseToSub(msg []byte) (se structs.SubsetEvent, err error) {
 	m := &authz.MsgRevokeResponse{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a revoke_response type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a revoke_response type: %w", err)
 	}
 
 	return structs.SubsetEvent{
@@ -0,0 +1,24 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/gogo/protobuf/proto"
+)
+
+func TestMapper_AuthzExecResponseToSub(t *testing.T) {
+	msg, err := proto.Marshal(&authz.MsgExecResponse{Results: [][]byte{[]byte("abc"), {0, 1, 2}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	se, err := (&Mapper{}).AuthzExecResponseToSub(msg)
+	if err != nil {
+		t.Fatalf("AuthzExecResponseToSub() error = %v", err)
+	}
+	results := se.Additional["results"]
+	if len(results) != 2 || results[0] != "YWJj" || results[1] != "AAEC" {
+		t.Errorf("AuthzExecResponseToSub() unexpected results %v", results)
+	}
+}
@@ -1,20 +1,20 @@
 package mapper
 
 import (
-	"fmt"
-
 	"github.com/figment-networks/indexing-engine/structs"
 
 	"github.com/cosmos/cosmos-sdk/types"
 	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/gogo/protobuf/proto"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
 )
 
 // BankMultisendToSub transforms bank.MsgMultiSend sdk messages to SubsetEvent
 func (mapper *Mapper) BankMultisendToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
 	multisend := &bank.MsgMultiSend{}
 	if err := proto.Unmarshal(msg, multisend); err != nil {
-		return se, fmt.Errorf("Not a multisend type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a multisend type: %w", err)
 	}
 
 	se = structs.SubsetEvent{
@@ -45,7 +45,7 @@ func (mapper *Mapper) BankMultisendToSub(msg []byte, lg types.ABCIMessageLog) (s
 func (mapper *Mapper) BankSendToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
 	send := &bank.MsgSend{}
 	if err := proto.Unmarshal(msg, send); err != nil {
-		return se, fmt.Errorf("Not a send type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a send type: %w", err)
 	}
 
 	se = structs.SubsetEvent{
@@ -0,0 +1,107 @@
+package mapper
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/figment-networks/indexing-engine/structs"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
+)
+
+// blockEvents are modules of the mapped BeginBlock and EndBlock events
+var blockEvents = map[string]string{
+	"complete_unbonding":    "staking",
+	"complete_redelegation": "staking",
+	"slash":                 "slashing",
+	"liveness":              "slashing",
+	"mint":                  "mint",
+	"active_proposal":       "gov",
+	"inactive_proposal":     "gov",
+}
+
+// BlockEventsToSub transforms BeginBlock and EndBlock events (ie of cosmosgrpc.Client.GetBlockResults)
+// to SubsetEvents. Events of the same type merged into one (as by types.StringifyEvents) are split again.
+func (mapper *Mapper) BlockEventsToSub(events types.StringEvents) (subs []structs.SubsetEvent, err error) {
+	for _, ev := range events {
+		module, ok := blockEvents[ev.Type]
+		if !ok {
+			continue
+		}
+		for _, attrs := range splitEvent(ev) {
+			se := structs.SubsetEvent{
+				Type:       []string{ev.Type},
+				Module:     module,
+				Node:       make(map[string][]structs.Account),
+				Amount:     make(map[string]structs.TransactionAmount),
+				Additional: make(map[string][]string),
+			}
+			if err := mapper.blockEventToSub(&se, ev.Type, attrs); err != nil {
+				return nil, err
+			}
+			subs = append(subs, se)
+		}
+	}
+	return subs, nil
+}
+
+func (mapper *Mapper) blockEventToSub(se *structs.SubsetEvent, evType string, attrs map[string]string) error {
+	for key, value := range attrs {
+		switch key {
+		case "delegator", "validator", "source_validator", "destination_validator":
+			se.Node[key] = []structs.Account{{ID: value}}
+		case "address":
+			// consensus address of slash and liveness events, validator nodes are operator addresses
+			se.Node["consensus_address"] = []structs.Account{{ID: value}}
+		case "jailed":
+			se.Node["jailed"] = []structs.Account{{ID: value}}
+		case "amount", "burned_coins":
+			if value == "" {
+				continue
+			}
+			amounts, err := mapper.blockAmounts(value)
+			if err != nil {
+				return err
+			}
+			for i, am := range amounts {
+				k := key
+				if i > 0 {
+					k += "_" + strconv.Itoa(i)
+				}
+				se.Amount[k] = am
+			}
+			if evType == "complete_unbonding" && len(amounts) > 0 {
+				se.Recipient = []structs.EventTransfer{{Account: structs.Account{ID: attrs["delegator"]}, Amounts: amounts}}
+			}
+		default:
+			se.Additional[key] = []string{value}
+		}
+	}
+	return nil
+}
+
+// blockAmounts parses amounts of the block events. Amounts of the mint event (and of staking events
+// before cosmos-sdk v0.43) have no denom, these are of the staking denom.
+func (mapper *Mapper) blockAmounts(value string) ([]structs.TransactionAmount, error) {
+	if n, ok := new(big.Int).SetString(value, 10); ok {
+		return []structs.TransactionAmount{{Currency: mapper.StakingDenom, Numeric: n, Text: value}}, nil
+	}
+	coins, err := types.ParseCoinsNormalized(value)
+	if err != nil {
+		return nil, util.Errorf(util.ErrAmountParse, "[COSMOS-API] Error parsing amount '%s': %w", value, err)
+	}
+	return CoinsToAmounts(coins), nil
+}
+
+// splitEvent splits attributes of events merged into one by the repeated keys
+func splitEvent(ev types.StringEvent) (split []map[string]string) {
+	for _, e := range splitStringEvent(ev) {
+		attrs := make(map[string]string, len(e.Attributes))
+		for _, attr := range e.Attributes {
+			attrs[attr.Key] = attr.Value
+		}
+		split = append(split, attrs)
+	}
+	return split
+}
@@ -0,0 +1,43 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestMapper_BlockEventsToSub(t *testing.T) {
+	events := types.StringifyEvents(types.Events{
+		types.NewEvent("mint", types.NewAttribute("bonded_ratio", "0.65"), types.NewAttribute("inflation", "0.1"), types.NewAttribute("amount", "1000")),
+		types.NewEvent("transfer", types.NewAttribute("recipient", "cosmos1distribution"), types.NewAttribute("amount", "1000uatom")),
+		types.NewEvent("complete_unbonding", types.NewAttribute("amount", "50uatom"), types.NewAttribute("validator", "cosmosvaloper1a"), types.NewAttribute("delegator", "cosmos1a")),
+		types.NewEvent("complete_unbonding", types.NewAttribute("amount", "70uatom"), types.NewAttribute("validator", "cosmosvaloper1b"), types.NewAttribute("delegator", "cosmos1b")),
+		types.NewEvent("slash", types.NewAttribute("address", "cosmosvalcons1a"), types.NewAttribute("power", "100"), types.NewAttribute("reason", "missing_signature"), types.NewAttribute("jailed", "cosmosvalcons1a")),
+		types.NewEvent("inactive_proposal", types.NewAttribute("proposal_id", "12"), types.NewAttribute("proposal_result", "proposal_dropped")),
+	}.ToABCIEvents())
+
+	subs, err := (&Mapper{StakingDenom: "uatom"}).BlockEventsToSub(events)
+	if err != nil {
+		t.Fatalf("Mapper.BlockEventsToSub() error = %v", err)
+	}
+	if len(subs) != 5 {
+		t.Fatalf("Mapper.BlockEventsToSub() expected 5 subevents, got %+v", subs)
+	}
+
+	// merged events are sorted by type
+	if m := subs[3]; m.Type[0] != "mint" || m.Amount["amount"].Currency != "uatom" || m.Amount["amount"].Text != "1000" || m.Additional["inflation"][0] != "0.1" {
+		t.Errorf("Mapper.BlockEventsToSub() unexpected mint %+v", m)
+	}
+	for i, want := range []string{"cosmos1a", "cosmos1b"} {
+		cu := subs[i]
+		if cu.Type[0] != "complete_unbonding" || cu.Module != "staking" || len(cu.Recipient) != 1 || cu.Recipient[0].Account.ID != want || cu.Node["delegator"][0].ID != want {
+			t.Errorf("Mapper.BlockEventsToSub() unexpected complete_unbonding %+v", cu)
+		}
+	}
+	if s := subs[4]; s.Type[0] != "slash" || s.Node["consensus_address"][0].ID != "cosmosvalcons1a" || len(s.Node["validator"]) != 0 || s.Additional["reason"][0] != "missing_signature" {
+		t.Errorf("Mapper.BlockEventsToSub() unexpected slash %+v", s)
+	}
+	if p := subs[2]; p.Module != "gov" || p.Additional["proposal_result"][0] != "proposal_dropped" {
+		t.Errorf("Mapper.BlockEventsToSub() unexpected proposal %+v", p)
+	}
+}
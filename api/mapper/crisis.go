@@ -1,19 +1,19 @@
 package mapper
 
 import (
-	"fmt"
-
 	"github.com/figment-networks/indexing-engine/structs"
 
 	crisis "github.com/cosmos/cosmos-sdk/x/crisis/types"
 	"github.com/gogo/protobuf/proto"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
 )
 
 // CrisisVerifyInvariantToSub transforms crisis.MsgVerifyInvariant sdk messages to SubsetEvent
 func (mapper *Mapper) CrisisVerifyInvariantToSub(msg []byte) (se structs.SubsetEvent, er error) {
 	mvi := &crisis.MsgVerifyInvariant{}
 	if err := proto.Unmarshal(msg, mvi); err != nil {
-		return se, fmt.Errorf("Not a crisis type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a crisis type: %w", err)
 	}
 
 	return structs.SubsetEvent{
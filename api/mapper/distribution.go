@@ -1,7 +1,6 @@
 package mapper
 
 import (
-	"fmt"
 	"math/big"
 
 	"github.com/figment-networks/indexing-engine/structs"
@@ -9,13 +8,15 @@ import (
 	"github.com/cosmos/cosmos-sdk/types"
 	distribution "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	"github.com/gogo/protobuf/proto"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
 )
 
 // DistributionWithdrawValidatorCommissionToSub transforms distribution.MsgWithdrawValidatorCommission sdk messages to SubsetEvent
 func (mapper *Mapper) DistributionWithdrawValidatorCommissionToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
 	wvc := &distribution.MsgWithdrawValidatorCommission{}
 	if err := proto.Unmarshal(msg, wvc); err != nil {
-		return se, fmt.Errorf("Not a distribution type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a distribution type: %w", err)
 	}
 
 	se = structs.SubsetEvent{
@@ -35,7 +36,7 @@ func (mapper *Mapper) DistributionWithdrawValidatorCommissionToSub(msg []byte, l
 func (mapper *Mapper) DistributionSetWithdrawAddressToSub(msg []byte) (se structs.SubsetEvent, err error) {
 	swa := &distribution.MsgSetWithdrawAddress{}
 	if err := proto.Unmarshal(msg, swa); err != nil {
-		return se, fmt.Errorf("Not a set_withdraw_address type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a set_withdraw_address type: %w", err)
 	}
 
 	return structs.SubsetEvent{
@@ -52,7 +53,7 @@ func (mapper *Mapper) DistributionSetWithdrawAddressToSub(msg []byte) (se struct
 func (mapper *Mapper) DistributionWithdrawDelegatorRewardToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
 	wdr := &distribution.MsgWithdrawDelegatorReward{}
 	if err := proto.Unmarshal(msg, wdr); err != nil {
-		return se, fmt.Errorf("Not a withdraw_validator_commission type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a withdraw_validator_commission type: %w", err)
 	}
 
 	se = structs.SubsetEvent{
@@ -75,7 +76,7 @@ func (mapper *Mapper) DistributionWithdrawDelegatorRewardToSub(msg []byte, lg ty
 func (mapper *Mapper) DistributionFundCommunityPoolToSub(msg []byte) (se structs.SubsetEvent, err error) {
 	fcp := &distribution.MsgFundCommunityPool{}
 	if err := proto.Unmarshal(msg, fcp); err != nil {
-		return se, fmt.Errorf("Not a fund_community_pool type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a fund_community_pool type: %w", err)
 	}
 
 	evt, err := distributionProduceEvTx(fcp.Depositor, fcp.Amount)
@@ -0,0 +1,107 @@
+package mapper
+
+import (
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/figment-networks/indexing-engine/structs"
+)
+
+// eventNodes are attributes of events with the accounts taking part in the message
+var eventNodes = map[string]bool{
+	"sender":                true,
+	"recipient":             true,
+	"spender":               true,
+	"receiver":              true,
+	"validator":             true,
+	"source_validator":      true,
+	"destination_validator": true,
+	"delegator":             true,
+	"granter":               true,
+	"grantee":               true,
+	"depositor":             true,
+	"voter":                 true,
+	"withdraw_address":      true,
+}
+
+// EventsToSub transforms the events of the message log to SubsetEvent without decoding the message,
+// it's the fallback for messages of unknown types. Senders and recipients are the balance changes of the
+// message Ledger, accounts are taken from the message event and attributes of other events.
+func (mapper *Mapper) EventsToSub(typeURL string, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	// TypeUrl is in the format "/cosmos.bank.v1beta1.MsgSend"
+	tPath := strings.Split(typeURL, ".")
+	se = structs.SubsetEvent{
+		Type:       []string{tPath[len(tPath)-1]},
+		Additional: map[string][]string{"type_url": {typeURL}},
+	}
+	if len(tPath) > 2 {
+		se.Module = tPath[1]
+	}
+
+	for _, ev := range lg.GetEvents() {
+		switch ev.GetType() {
+		case "message":
+			for _, attr := range ev.GetAttributes() {
+				switch attr.Key {
+				case "module":
+					se.Module = attr.Value
+				case "sender":
+					addNode(&se, "sender", attr.Value)
+				case "action":
+					se.Action = attr.Value
+				}
+			}
+		case "coin_spent", "coin_received", "transfer", "burn", "coinbase":
+			// balance changes are added from the ledger
+		default:
+			for _, attr := range ev.GetAttributes() {
+				if eventNodes[attr.Key] {
+					addNode(&se, attr.Key, attr.Value)
+					continue
+				}
+				key := ev.GetType() + "_" + attr.Key
+				se.Additional[key] = append(se.Additional[key], attr.Value)
+			}
+		}
+	}
+
+	l, err := NewLedger(lg.GetEvents(), SourceTx)
+	if err != nil {
+		return se, err
+	}
+	l.AddToSub(&se)
+
+	// transfer events repeat the ledger changes, they are mapped only for logs without coin events (cosmos-sdk < v0.43)
+	if !hasCoinEvents(lg.GetEvents()) {
+		err = produceTransfers(&se, "transfer", "", lg)
+	}
+	return se, err
+}
+
+func addNode(se *structs.SubsetEvent, key, account string) {
+	if se.Node == nil {
+		se.Node = make(map[string][]structs.Account)
+	}
+	for _, acc := range se.Node[key] {
+		if acc.ID == account {
+			return
+		}
+	}
+	se.Node[key] = append(se.Node[key], structs.Account{ID: account})
+}
+
+// splitStringEvent splits events merged into one (as by types.StringifyEvents) by the repeated keys
+func splitStringEvent(ev types.StringEvent) (split []types.StringEvent) {
+	var current *types.StringEvent
+	keys := map[string]bool{}
+	for _, attr := range ev.Attributes {
+		if current == nil || keys[attr.Key] {
+			split = append(split, types.StringEvent{Type: ev.Type})
+			current = &split[len(split)-1]
+			keys = map[string]bool{}
+		}
+		keys[attr.Key] = true
+		current.Attributes = append(current.Attributes, attr)
+	}
+	return split
+}
@@ -0,0 +1,73 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestMapper_EventsToSub(t *testing.T) {
+	lg := types.ABCIMessageLog{Events: types.StringEvents{
+		{Type: "message", Attributes: []types.Attribute{
+			{Key: "action", Value: "/stride.stakeibc.MsgLiquidStake"}, {Key: "sender", Value: "stride1sender"}, {Key: "module", Value: "stakeibc"},
+		}},
+		{Type: "coin_spent", Attributes: []types.Attribute{
+			{Key: "spender", Value: "stride1sender"}, {Key: "amount", Value: "1000ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"},
+			{Key: "spender", Value: "stride1module"}, {Key: "amount", Value: "990stuatom"},
+		}},
+		{Type: "coin_received", Attributes: []types.Attribute{
+			{Key: "receiver", Value: "stride1module"}, {Key: "amount", Value: "1000ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"},
+			{Key: "receiver", Value: "stride1sender"}, {Key: "amount", Value: "990stuatom"},
+		}},
+		{Type: "transfer", Attributes: []types.Attribute{
+			{Key: "recipient", Value: "stride1sender"}, {Key: "sender", Value: "stride1module"}, {Key: "amount", Value: "990stuatom"},
+		}},
+		{Type: "liquid_stake", Attributes: []types.Attribute{
+			{Key: "liquid_staker", Value: "stride1sender"}, {Key: "host_zone", Value: "cosmoshub-4"},
+		}},
+	}}
+
+	se, err := (&Mapper{}).EventsToSub("/stride.stakeibc.MsgLiquidStake", lg)
+	if err != nil {
+		t.Fatalf("Mapper.EventsToSub() error = %v", err)
+	}
+	if se.Type[0] != "MsgLiquidStake" || se.Module != "stakeibc" || se.Action != "/stride.stakeibc.MsgLiquidStake" {
+		t.Errorf("Mapper.EventsToSub() unexpected type %v %s %s", se.Type, se.Module, se.Action)
+	}
+	if s := se.Node["sender"]; len(s) != 1 || s[0].ID != "stride1sender" {
+		t.Errorf("Mapper.EventsToSub() unexpected sender %+v", s)
+	}
+	if len(se.Sender) != 2 || se.Sender[0].Account.ID != "stride1sender" || se.Sender[1].Amounts[0].Currency != "stuatom" {
+		t.Errorf("Mapper.EventsToSub() unexpected senders %+v", se.Sender)
+	}
+	if len(se.Recipient) != 2 || se.Recipient[1].Account.ID != "stride1sender" || se.Recipient[1].Amounts[0].Text != "990" {
+		t.Errorf("Mapper.EventsToSub() unexpected recipients %+v", se.Recipient)
+	}
+	// transfers repeat the coin events already in senders and recipients
+	if tr, ok := se.Transfers["transfer"]; ok {
+		t.Errorf("Mapper.EventsToSub() unexpected transfers %+v", tr)
+	}
+	if hz := se.Additional["liquid_stake_host_zone"]; len(hz) != 1 || hz[0] != "cosmoshub-4" {
+		t.Errorf("Mapper.EventsToSub() unexpected additional %v", se.Additional)
+	}
+}
+
+func TestMapper_EventsToSub_Transfers(t *testing.T) {
+	lg := types.ABCIMessageLog{Events: types.StringEvents{
+		{Type: "message", Attributes: []types.Attribute{{Key: "action", Value: "liquid_stake"}, {Key: "sender", Value: "stride1sender"}}},
+		{Type: "transfer", Attributes: []types.Attribute{
+			{Key: "recipient", Value: "stride1module"}, {Key: "sender", Value: "stride1sender"}, {Key: "amount", Value: "1000uatom"},
+		}},
+	}}
+
+	se, err := (&Mapper{}).EventsToSub("/stride.stakeibc.MsgLiquidStake", lg)
+	if err != nil {
+		t.Fatalf("Mapper.EventsToSub() error = %v", err)
+	}
+	if len(se.Sender) != 1 || len(se.Recipient) != 1 || se.Recipient[0].Account.ID != "stride1module" {
+		t.Errorf("Mapper.EventsToSub() unexpected senders %+v recipients %+v", se.Sender, se.Recipient)
+	}
+	if tr := se.Transfers["transfer"]; len(tr) != 1 || tr[0].Account.ID != "stride1module" {
+		t.Errorf("Mapper.EventsToSub() unexpected transfers %+v", tr)
+	}
+}
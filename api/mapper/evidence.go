@@ -1,8 +1,6 @@
 package mapper
 
 import (
-	"errors"
-	"fmt"
 	"strconv"
 
 	"github.com/figment-networks/indexing-engine/structs"
@@ -10,13 +8,15 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/evidence/exported"
 	evidence "github.com/cosmos/cosmos-sdk/x/evidence/types"
 	"github.com/gogo/protobuf/proto"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
 )
 
 // EvidenceSubmitEvidenceToSub transforms evidence.MsgSubmitEvidence sdk messages to SubsetEvent
 func (mapper *Mapper) EvidenceSubmitEvidenceToSub(msg []byte) (se structs.SubsetEvent, er error) {
 	mse := &evidence.MsgSubmitEvidence{}
 	if err := proto.Unmarshal(msg, mse); err != nil {
-		return se, fmt.Errorf("Not a submit_evidence type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a submit_evidence type: %w", err)
 	}
 
 	se = structs.SubsetEvent{
@@ -27,7 +27,7 @@ func (mapper *Mapper) EvidenceSubmitEvidenceToSub(msg []byte) (se structs.Subset
 
 	ev := mse.GetEvidence()
 	if ev == nil {
-		return se, errors.New("Evidence is empty")
+		return se, util.Errorf(util.ErrMalformedMessage, "Evidence is empty")
 	}
 
 	se.Additional = map[string][]string{
@@ -36,12 +36,12 @@ func (mapper *Mapper) EvidenceSubmitEvidenceToSub(msg []byte) (se structs.Subset
 
 	evc := mse.Evidence.GetCachedValue()
 	if evc == nil {
-		return se, errors.New("Evidence is empty")
+		return se, util.Errorf(util.ErrMalformedMessage, "Evidence is empty")
 	}
 
 	validatorEvi, ok := evc.(exported.ValidatorEvidence)
 	if !ok {
-		return se, errors.New("Evidence is not ValidatorEvidence type")
+		return se, util.Errorf(util.ErrMalformedMessage, "Evidence is not ValidatorEvidence type")
 	}
 
 	se.Additional["evidence_total_power"] = []string{strconv.FormatInt(validatorEvi.GetTotalPower(), 10)}
@@ -1,43 +1,43 @@
 package mapper
 
 import (
-	"fmt"
-
 	"github.com/figment-networks/indexing-engine/structs"
-	"github.com/figment-networks/ni-cosmoslib/util"
 
 	"github.com/cosmos/cosmos-sdk/x/feegrant"
 	"github.com/gogo/protobuf/proto"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
 )
 
 // FeegrantGrantAllowance transforms feegrant.MsgGrantAllowance sdk messages to SubsetEvent
 func (mapper *Mapper) FeegrantGrantAllowance(msg []byte) (se structs.SubsetEvent, err error) {
 	m := &feegrant.MsgGrantAllowance{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a grant_allowance type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a grant_allowance type: %w", err)
 	}
 
-	allowance, err := util.EncodeToB64(m.Allowance.Value, "allowance")
-	if err != nil {
-		return se, err
-	}
-
-	return structs.SubsetEvent{
+	se = structs.SubsetEvent{
 		Type:   []string{"grant_allowance"},
 		Module: "feegrant",
+		Node: map[string][]structs.Account{
+			"granter": {{ID: m.Granter}},
+			"grantee": {{ID: m.Grantee}},
+		},
 		Additional: map[string][]string{
-			"granter":   {m.Granter},
-			"grantee":   {m.Grantee},
-			"allowance": {allowance},
+			"granter": {m.Granter},
+			"grantee": {m.Grantee},
 		},
-	}, nil
+	}
+
+	err = allowanceToSub(&se, m.Allowance)
+	return se, err
 }
 
 // FeegrantGrantAllowanceResponse transforms feegrant.MsgGrantAllowanceResponse sdk messages to SubsetEvent
 func (mapper *Mapper) FeegrantGrantAllowanceResponse(msg []byte) (se structs.SubsetEvent, err error) {
 	m := &feegrant.MsgGrantAllowanceResponse{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a grant_allowance_response type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a grant_allowance_response type: %w", err)
 	}
 
 	return structs.SubsetEvent{
@@ -50,7 +50,7 @@ func (mapper *Mapper) FeegrantGrantAllowanceResponse(msg []byte) (se structs.Sub
 func (mapper *Mapper) FeegrantRevokeAllowance(msg []byte) (se structs.SubsetEvent, err error) {
 	m := &feegrant.MsgRevokeAllowance{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a revoke_allowance type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a revoke_allowance type: %w", err)
 	}
 
 	return structs.SubsetEvent{
@@ -67,7 +67,7 @@ func (mapper *Mapper) FeegrantRevokeAllowance(msg []byte) (se structs.SubsetEven
 func (mapper *Mapper) FeegrantRevokeAllowanceResponse(msg []byte) (se structs.SubsetEvent, err error) {
 	m := &feegrant.MsgRevokeAllowanceResponse{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a revoke_allowance_response type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a revoke_allowance_response type: %w", err)
 	}
 
 	return structs.SubsetEvent{
@@ -1,7 +1,6 @@
 package mapper
 
 import (
-	"fmt"
 	"strconv"
 
 	"github.com/figment-networks/indexing-engine/structs"
@@ -9,13 +8,15 @@ import (
 	"github.com/cosmos/cosmos-sdk/types"
 	gov "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"github.com/gogo/protobuf/proto"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
 )
 
 // GovDepositToSub transforms gov.MsgDeposit sdk messages to SubsetEvent
 func (mapper *Mapper) GovDepositToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
 	dep := &gov.MsgDeposit{}
 	if err := proto.Unmarshal(msg, dep); err != nil {
-		return se, fmt.Errorf("Not a deposit type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a deposit type: %w", err)
 	}
 
 	se = structs.SubsetEvent{
@@ -55,7 +56,7 @@ func (mapper *Mapper) GovDepositToSub(msg []byte, lg types.ABCIMessageLog) (se s
 func (mapper *Mapper) GovVoteToSub(msg []byte) (se structs.SubsetEvent, err error) {
 	vote := &gov.MsgVote{}
 	if err := proto.Unmarshal(msg, vote); err != nil {
-		return se, fmt.Errorf("Not a vote type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a vote type: %w", err)
 	}
 
 	return structs.SubsetEvent{
@@ -73,7 +74,7 @@ func (mapper *Mapper) GovVoteToSub(msg []byte) (se structs.SubsetEvent, err erro
 func (mapper *Mapper) GovSubmitProposalToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
 	sp := &gov.MsgSubmitProposal{}
 	if err := proto.Unmarshal(msg, sp); err != nil {
-		return se, fmt.Errorf("Not a submit_proposal type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a submit_proposal type: %w", err)
 	}
 
 	se = structs.SubsetEvent{
@@ -137,7 +138,7 @@ func (mapper *Mapper) GovSubmitProposalToSub(msg []byte, lg types.ABCIMessageLog
 func (mapper *Mapper) GovMsgVoteWeighted(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
 	sp := &gov.MsgVoteWeighted{}
 	if err := proto.Unmarshal(msg, sp); err != nil {
-		return se, fmt.Errorf("Not a vote_weighted type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a vote_weighted type: %w", err)
 	}
 
 	se = structs.SubsetEvent{
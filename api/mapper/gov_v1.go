@@ -0,0 +1,276 @@
+package mapper
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/figment-networks/indexing-engine/structs"
+	"github.com/figment-networks/ni-cosmoslib/util"
+
+	basev1beta1 "cosmossdk.io/api/cosmos/base/v1beta1"
+	govv1 "cosmossdk.io/api/cosmos/gov/v1"
+	codec_types "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	distribution "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	gov "github.com/cosmos/cosmos-sdk/x/gov/types"
+	params "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
+	upgrade "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/gogo/protobuf/proto"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// gov v1 messages (cosmos-sdk v0.46+) are decoded using cosmossdk.io/api types,
+// as the cosmos-sdk version containing them can't be used together with the liquidity module.
+
+// GovV1SubmitProposalToSub transforms gov v1.MsgSubmitProposal sdk messages to SubsetEvent,
+// proposal messages are mapped by sub and added to Sub
+func (mapper *Mapper) GovV1SubmitProposalToSub(msg []byte, lg types.ABCIMessageLog, sub SubMapper) (se structs.SubsetEvent, err error) {
+	sp := &govv1.MsgSubmitProposal{}
+	if err := protov2.Unmarshal(msg, sp); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a submit_proposal type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:       []string{"submit_proposal"},
+		Module:     "gov",
+		Node:       map[string][]structs.Account{"proposer": {{ID: sp.Proposer}}},
+		Additional: map[string][]string{},
+	}
+
+	if sp.Metadata != "" {
+		se.Additional["metadata"] = []string{sp.Metadata}
+	}
+	if sp.Title != "" {
+		se.Additional["title"] = []string{sp.Title}
+	}
+	if sp.Summary != "" {
+		se.Additional["summary"] = []string{sp.Summary}
+	}
+
+	deposit, err := coinsFromV1(sp.InitialDeposit)
+	if err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a submit_proposal type: %w", err)
+	}
+	CoinsToAmount(&se, "initial_deposit", deposit)
+	se.Sender = []structs.EventTransfer{{
+		Account: structs.Account{ID: sp.Proposer},
+		Amounts: CoinsToAmounts(deposit),
+	}}
+
+	if err = produceTransfers(&se, "send", "", lg); err != nil {
+		return se, err
+	}
+
+	err = proposalMessagesToSub(&se, sp.Messages, lg, sub)
+	return se, err
+}
+
+// proposalMessagesToSub maps proposal messages by sub and adds them to Sub, the ones that are not supported
+// are only listed in "messages_type_url". Every message is mapped even if some of them fail, the returned
+// error is the first mapping error.
+func proposalMessagesToSub(se *structs.SubsetEvent, msgs []*anypb.Any, lg types.ABCIMessageLog, sub SubMapper) (err error) {
+	// proposal messages are not executed with the message itself, so they have no logs
+	subLg := types.ABCIMessageLog{MsgIndex: lg.MsgIndex}
+	for _, m := range msgs {
+		se.Additional["messages_type_url"] = append(se.Additional["messages_type_url"], m.TypeUrl)
+
+		ev, subErr := sub(&codec_types.Any{TypeUrl: m.TypeUrl, Value: m.Value}, subLg)
+		if subErr != nil && !errors.Is(subErr, util.ErrUnknownMessageType) && err == nil {
+			err = fmt.Errorf("problem with proposal message %s: %w", m.TypeUrl, subErr)
+		}
+		if len(ev.Type) > 0 {
+			se.Sub = append(se.Sub, ev)
+		}
+	}
+	return err
+}
+
+// GovV1ExecLegacyContentToSub transforms gov v1.MsgExecLegacyContent sdk messages to SubsetEvent
+func (mapper *Mapper) GovV1ExecLegacyContentToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	elc := &govv1.MsgExecLegacyContent{}
+	if err := protov2.Unmarshal(msg, elc); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a exec_legacy_content type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:       []string{"exec_legacy_content"},
+		Module:     "gov",
+		Node:       map[string][]structs.Account{"authority": {{ID: elc.Authority}}},
+		Additional: map[string][]string{"authority": {elc.Authority}},
+	}
+
+	if err = legacyContentToSub(&se, elc.Content); err != nil {
+		return se, err
+	}
+
+	err = produceTransfers(&se, "send", "", lg)
+	return se, err
+}
+
+// GovV1DepositToSub transforms gov v1.MsgDeposit sdk messages to SubsetEvent
+func (mapper *Mapper) GovV1DepositToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	dep := &govv1.MsgDeposit{}
+	if err := protov2.Unmarshal(msg, dep); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a deposit type: %w", err)
+	}
+
+	amount, err := coinsFromV1(dep.Amount)
+	if err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a deposit type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:       []string{"deposit"},
+		Module:     "gov",
+		Node:       map[string][]structs.Account{"depositor": {{ID: dep.Depositor}}},
+		Additional: map[string][]string{"proposal_id": {strconv.FormatUint(dep.ProposalId, 10)}},
+		Sender: []structs.EventTransfer{{
+			Account: structs.Account{ID: dep.Depositor},
+			Amounts: CoinsToAmounts(amount),
+		}},
+	}
+	CoinsToAmount(&se, "deposit", amount)
+
+	err = produceTransfers(&se, "send", "", lg)
+	return se, err
+}
+
+// GovV1VoteToSub transforms gov v1.MsgVote sdk messages to SubsetEvent
+func (mapper *Mapper) GovV1VoteToSub(msg []byte) (se structs.SubsetEvent, err error) {
+	vote := &govv1.MsgVote{}
+	if err := protov2.Unmarshal(msg, vote); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a vote type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:   []string{"vote"},
+		Module: "gov",
+		Node:   map[string][]structs.Account{"voter": {{ID: vote.Voter}}},
+		Additional: map[string][]string{
+			"proposal_id": {strconv.FormatUint(vote.ProposalId, 10)},
+			"option":      {vote.Option.String()},
+		},
+	}
+	if vote.Metadata != "" {
+		se.Additional["metadata"] = []string{vote.Metadata}
+	}
+	return se, nil
+}
+
+// GovV1VoteWeightedToSub transforms gov v1.MsgVoteWeighted sdk messages to SubsetEvent
+func (mapper *Mapper) GovV1VoteWeightedToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	sp := &govv1.MsgVoteWeighted{}
+	if err := protov2.Unmarshal(msg, sp); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a vote_weighted type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:       []string{"vote_weighted"},
+		Module:     "gov",
+		Node:       map[string][]structs.Account{"voter": {{ID: sp.Voter}}},
+		Additional: make(map[string][]string),
+	}
+
+	err = produceTransfers(&se, "send", "", lg)
+	if err != nil {
+		return se, err
+	}
+
+	se.Additional["voter"] = []string{sp.Voter}
+	se.Additional["proposal_id"] = []string{strconv.FormatUint(sp.ProposalId, 10)}
+	options := []string{}
+	weights := []string{}
+	for _, option := range sp.Options {
+		options = append(options, strconv.FormatInt(int64(option.Option), 10))
+		weights = append(weights, option.Weight)
+	}
+	se.Additional["options"] = options
+	se.Additional["weights"] = weights
+	if sp.Metadata != "" {
+		se.Additional["metadata"] = []string{sp.Metadata}
+	}
+	return se, nil
+}
+
+// legacyContent is the v1beta1 proposal content
+type legacyContent interface {
+	gov.Content
+	proto.Message
+}
+
+// legacyContents are the v1beta1 proposal contents that can be decoded
+var legacyContents = map[string]func() legacyContent{
+	"/cosmos.gov.v1beta1.TextProposal":                        func() legacyContent { return &gov.TextProposal{} },
+	"/cosmos.params.v1beta1.ParameterChangeProposal":          func() legacyContent { return &params.ParameterChangeProposal{} },
+	"/cosmos.upgrade.v1beta1.SoftwareUpgradeProposal":         func() legacyContent { return &upgrade.SoftwareUpgradeProposal{} },
+	"/cosmos.upgrade.v1beta1.CancelSoftwareUpgradeProposal":   func() legacyContent { return &upgrade.CancelSoftwareUpgradeProposal{} },
+	"/cosmos.distribution.v1beta1.CommunityPoolSpendProposal": func() legacyContent { return &distribution.CommunityPoolSpendProposal{} },
+}
+
+// legacyContentToSub adds decoded v1beta1 proposal content to SubsetEvent
+func legacyContentToSub(se *structs.SubsetEvent, content *anypb.Any) error {
+	if content == nil {
+		return nil
+	}
+	se.Additional["content_type_url"] = []string{content.TypeUrl}
+
+	newContent, ok := legacyContents[content.TypeUrl]
+	if !ok {
+		// Encode fields that can contain null bytes.
+		c, err := util.EncodeToB64(content.Value, "content")
+		if err != nil {
+			return err
+		}
+		se.Additional["content"] = []string{c}
+		return nil
+	}
+
+	c := newContent()
+	if err := proto.Unmarshal(content.Value, c); err != nil {
+		return util.Errorf(util.ErrMalformedMessage, "Not a legacy_content type: %w", err)
+	}
+
+	se.Additional["proposal_route"] = []string{c.ProposalRoute()}
+	se.Additional["proposal_type"] = []string{c.ProposalType()}
+	if c.GetTitle() != "" {
+		se.Additional["title"] = []string{c.GetTitle()}
+	}
+	if c.GetDescription() != "" {
+		se.Additional["description"] = []string{c.GetDescription()}
+	}
+	if cps, ok := c.(*distribution.CommunityPoolSpendProposal); ok {
+		se.Additional["recipient"] = []string{cps.Recipient}
+		CoinsToAmount(se, "amount", cps.Amount)
+	}
+	se.Additional["content"] = []string{c.String()}
+
+	return nil
+}
+
+// coinsFromV1 converts coins decoded with cosmossdk.io/api types
+func coinsFromV1(coins []*basev1beta1.Coin) (types.Coins, error) {
+	cs := make(types.Coins, 0, len(coins))
+	for _, coin := range coins {
+		amount, ok := types.NewIntFromString(coin.Amount)
+		if !ok {
+			return nil, util.Errorf(util.ErrAmountParse, "invalid amount %q of %s", coin.Amount, coin.Denom)
+		}
+		cs = append(cs, types.Coin{Denom: coin.Denom, Amount: amount})
+	}
+	return cs, nil
+}
+
+// CoinsToAmounts converts coins to TransactionAmounts
+func CoinsToAmounts(coins types.Coins) []structs.TransactionAmount {
+	var amounts []structs.TransactionAmount
+	for _, coin := range coins {
+		amounts = append(amounts, structs.TransactionAmount{
+			Currency: coin.Denom,
+			Numeric:  coin.Amount.BigInt(),
+			Text:     coin.Amount.String(),
+		})
+	}
+	return amounts
+}
@@ -0,0 +1,270 @@
+package mapper
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/figment-networks/indexing-engine/structs"
+	"github.com/figment-networks/ni-cosmoslib/util"
+
+	groupv1 "cosmossdk.io/api/cosmos/group/v1"
+	"github.com/cosmos/cosmos-sdk/types"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// group messages (cosmos-sdk v0.46+) are decoded using cosmossdk.io/api types, same as gov v1.
+
+// GroupCreateGroupToSub transforms group.MsgCreateGroup sdk messages to SubsetEvent
+func (mapper *Mapper) GroupCreateGroupToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	m := &groupv1.MsgCreateGroup{}
+	if err := protov2.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a create_group type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:       []string{"create_group"},
+		Module:     "group",
+		Node:       map[string][]structs.Account{"admin": {{ID: m.Admin}}},
+		Additional: map[string][]string{"admin": {m.Admin}},
+	}
+	if m.Metadata != "" {
+		se.Additional["metadata"] = []string{m.Metadata}
+	}
+	groupMembersToSub(&se, m.Members)
+	if groupID, ok := groupEventAttribute(lg, "cosmos.group.v1.EventCreateGroup", "group_id"); ok {
+		se.Additional["group_id"] = []string{groupID}
+	}
+
+	return se, nil
+}
+
+// GroupUpdateGroupMembersToSub transforms group.MsgUpdateGroupMembers sdk messages to SubsetEvent
+func (mapper *Mapper) GroupUpdateGroupMembersToSub(msg []byte) (se structs.SubsetEvent, err error) {
+	m := &groupv1.MsgUpdateGroupMembers{}
+	if err := protov2.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a update_group_members type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:   []string{"update_group_members"},
+		Module: "group",
+		Node:   map[string][]structs.Account{"admin": {{ID: m.Admin}}},
+		Additional: map[string][]string{
+			"admin":    {m.Admin},
+			"group_id": {strconv.FormatUint(m.GroupId, 10)},
+		},
+	}
+	// members with weight 0 are removed from the group
+	groupMembersToSub(&se, m.MemberUpdates)
+
+	return se, nil
+}
+
+// GroupCreateGroupPolicyToSub transforms group.MsgCreateGroupPolicy sdk messages to SubsetEvent
+func (mapper *Mapper) GroupCreateGroupPolicyToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	m := &groupv1.MsgCreateGroupPolicy{}
+	if err := protov2.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a create_group_policy type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:   []string{"create_group_policy"},
+		Module: "group",
+		Node:   map[string][]structs.Account{"admin": {{ID: m.Admin}}},
+		Additional: map[string][]string{
+			"admin":    {m.Admin},
+			"group_id": {strconv.FormatUint(m.GroupId, 10)},
+		},
+	}
+	if m.Metadata != "" {
+		se.Additional["metadata"] = []string{m.Metadata}
+	}
+	if address, ok := groupEventAttribute(lg, "cosmos.group.v1.EventCreateGroupPolicy", "address"); ok {
+		se.Node["group_policy"] = []structs.Account{{ID: address}}
+		se.Additional["group_policy_address"] = []string{address}
+	}
+
+	err = decisionPolicyToSub(&se, m.DecisionPolicy)
+	return se, err
+}
+
+// GroupSubmitProposalToSub transforms group.MsgSubmitProposal sdk messages to SubsetEvent,
+// proposal messages are mapped by sub and added to Sub
+func (mapper *Mapper) GroupSubmitProposalToSub(msg []byte, lg types.ABCIMessageLog, sub SubMapper) (se structs.SubsetEvent, err error) {
+	m := &groupv1.MsgSubmitProposal{}
+	if err := protov2.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a submit_proposal type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:   []string{"submit_proposal"},
+		Module: "group",
+		Node: map[string][]structs.Account{
+			"group_policy": {{ID: m.GroupPolicyAddress}},
+		},
+		Additional: map[string][]string{
+			"group_policy_address": {m.GroupPolicyAddress},
+			"proposers":            m.Proposers,
+			"exec":                 {m.Exec.String()},
+		},
+	}
+	for _, proposer := range m.Proposers {
+		se.Node["proposer"] = append(se.Node["proposer"], structs.Account{ID: proposer})
+	}
+	if m.Metadata != "" {
+		se.Additional["metadata"] = []string{m.Metadata}
+	}
+	if m.Title != "" {
+		se.Additional["title"] = []string{m.Title}
+	}
+	if m.Summary != "" {
+		se.Additional["summary"] = []string{m.Summary}
+	}
+	if proposalID, ok := groupEventAttribute(lg, "cosmos.group.v1.EventSubmitProposal", "proposal_id"); ok {
+		se.Additional["proposal_id"] = []string{proposalID}
+	}
+	groupExecResultToSub(&se, lg)
+
+	// messages are executed in the same transaction for exec = EXEC_TRY
+	if err = produceTransfers(&se, "send", "", lg); err != nil {
+		return se, err
+	}
+
+	err = proposalMessagesToSub(&se, m.Messages, lg, sub)
+	return se, err
+}
+
+// GroupVoteToSub transforms group.MsgVote sdk messages to SubsetEvent
+func (mapper *Mapper) GroupVoteToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	m := &groupv1.MsgVote{}
+	if err := protov2.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a vote type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:   []string{"vote"},
+		Module: "group",
+		Node:   map[string][]structs.Account{"voter": {{ID: m.Voter}}},
+		Additional: map[string][]string{
+			"proposal_id": {strconv.FormatUint(m.ProposalId, 10)},
+			"option":      {m.Option.String()},
+			"exec":        {m.Exec.String()},
+		},
+	}
+	if m.Metadata != "" {
+		se.Additional["metadata"] = []string{m.Metadata}
+	}
+	groupExecResultToSub(&se, lg)
+
+	// proposal is executed in the same transaction for exec = EXEC_TRY
+	err = produceTransfers(&se, "send", "", lg)
+	return se, err
+}
+
+// GroupExecToSub transforms group.MsgExec sdk messages to SubsetEvent
+func (mapper *Mapper) GroupExecToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	m := &groupv1.MsgExec{}
+	if err := protov2.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a exec type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:   []string{"exec"},
+		Module: "group",
+		Node:   map[string][]structs.Account{"executor": {{ID: m.Executor}}},
+		Additional: map[string][]string{
+			"proposal_id": {strconv.FormatUint(m.ProposalId, 10)},
+		},
+	}
+	groupExecResultToSub(&se, lg)
+
+	err = produceTransfers(&se, "send", "", lg)
+	return se, err
+}
+
+// GroupLeaveGroupToSub transforms group.MsgLeaveGroup sdk messages to SubsetEvent
+func (mapper *Mapper) GroupLeaveGroupToSub(msg []byte) (se structs.SubsetEvent, err error) {
+	m := &groupv1.MsgLeaveGroup{}
+	if err := protov2.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a leave_group type: %w", err)
+	}
+
+	return structs.SubsetEvent{
+		Type:   []string{"leave_group"},
+		Module: "group",
+		Node:   map[string][]structs.Account{"member": {{ID: m.Address}}},
+		Additional: map[string][]string{
+			"member":   {m.Address},
+			"group_id": {strconv.FormatUint(m.GroupId, 10)},
+		},
+	}, nil
+}
+
+// groupMembersToSub adds members with their weights to SubsetEvent
+func groupMembersToSub(se *structs.SubsetEvent, members []*groupv1.MemberRequest) {
+	for _, member := range members {
+		se.Node["member"] = append(se.Node["member"], structs.Account{ID: member.Address})
+		se.Additional["members"] = append(se.Additional["members"], member.Address)
+		se.Additional["members_weight"] = append(se.Additional["members_weight"], member.Weight)
+		se.Additional["members_metadata"] = append(se.Additional["members_metadata"], member.Metadata)
+	}
+}
+
+// decisionPolicyToSub adds decoded group policy decision policy to SubsetEvent
+func decisionPolicyToSub(se *structs.SubsetEvent, policy *anypb.Any) error {
+	if policy == nil {
+		return nil
+	}
+	se.Additional["decision_policy_type_url"] = []string{policy.TypeUrl}
+
+	var windows *groupv1.DecisionPolicyWindows
+	switch policy.TypeUrl {
+	case "/cosmos.group.v1.ThresholdDecisionPolicy":
+		p := &groupv1.ThresholdDecisionPolicy{}
+		if err := protov2.Unmarshal(policy.Value, p); err != nil {
+			return util.Errorf(util.ErrMalformedMessage, "Not a threshold_decision_policy type: %w", err)
+		}
+		se.Additional["threshold"] = []string{p.Threshold}
+		windows = p.Windows
+	case "/cosmos.group.v1.PercentageDecisionPolicy":
+		p := &groupv1.PercentageDecisionPolicy{}
+		if err := protov2.Unmarshal(policy.Value, p); err != nil {
+			return util.Errorf(util.ErrMalformedMessage, "Not a percentage_decision_policy type: %w", err)
+		}
+		se.Additional["percentage"] = []string{p.Percentage}
+		windows = p.Windows
+	default:
+		// Encode fields that can contain null bytes.
+		p, err := util.EncodeToB64(policy.Value, "decision_policy")
+		if err != nil {
+			return err
+		}
+		se.Additional["decision_policy"] = []string{p}
+		return nil
+	}
+
+	if windows != nil {
+		se.Additional["voting_period"] = []string{durationString(windows.VotingPeriod)}
+		se.Additional["min_execution_period"] = []string{durationString(windows.MinExecutionPeriod)}
+	}
+	return nil
+}
+
+// groupExecResultToSub adds the result of proposal execution if the proposal was executed
+func groupExecResultToSub(se *structs.SubsetEvent, lg types.ABCIMessageLog) {
+	if result, ok := groupEventAttribute(lg, "cosmos.group.v1.EventExec", "result"); ok {
+		se.Additional["result"] = []string{result}
+	}
+}
+
+// groupEventAttribute returns value of the typed event attribute, these are json encoded
+func groupEventAttribute(lg types.ABCIMessageLog, eventType, key string) (string, bool) {
+	v, ok := eventAttribute(lg, eventType, key)
+	return strings.Trim(v, `"`), ok
+}
+
+func durationString(d *durationpb.Duration) string {
+	return d.AsDuration().String()
+}
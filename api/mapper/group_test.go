@@ -0,0 +1,94 @@
+package mapper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	groupv1 "cosmossdk.io/api/cosmos/group/v1"
+	codec_types "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/figment-networks/indexing-engine/structs"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestMapper_GroupCreateGroupPolicyToSub(t *testing.T) {
+	policy, err := protov2.Marshal(&groupv1.ThresholdDecisionPolicy{
+		Threshold: "2",
+		Windows:   &groupv1.DecisionPolicyWindows{VotingPeriod: durationpb.New(24 * time.Hour)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := protov2.Marshal(&groupv1.MsgCreateGroupPolicy{
+		Admin:          "cosmos1admin",
+		GroupId:        3,
+		DecisionPolicy: &anypb.Any{TypeUrl: "/cosmos.group.v1.ThresholdDecisionPolicy", Value: policy},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lg := types.ABCIMessageLog{Events: []types.StringEvent{
+		{Type: "cosmos.group.v1.EventCreateGroupPolicy", Attributes: []types.Attribute{{Key: "address", Value: `"cosmos1policy"`}}},
+	}}
+
+	se, err := (&Mapper{}).GroupCreateGroupPolicyToSub(msg, lg)
+	if err != nil {
+		t.Fatalf("Mapper.GroupCreateGroupPolicyToSub() error = %v", err)
+	}
+
+	want := map[string][]string{
+		"admin":                    {"cosmos1admin"},
+		"group_id":                 {"3"},
+		"group_policy_address":     {"cosmos1policy"},
+		"decision_policy_type_url": {"/cosmos.group.v1.ThresholdDecisionPolicy"},
+		"threshold":                {"2"},
+		"voting_period":            {"24h0m0s"},
+		"min_execution_period":     {"0s"},
+	}
+	if !reflect.DeepEqual(se.Additional, want) {
+		t.Errorf("Mapper.GroupCreateGroupPolicyToSub() = %v, want %v", se.Additional, want)
+	}
+}
+
+func TestMapper_GroupSubmitProposalToSub(t *testing.T) {
+	send, err := codec_types.NewAnyWithValue(&bank.MsgSend{
+		FromAddress: "cosmos1policy",
+		ToAddress:   "cosmos1recipient",
+		Amount:      types.NewCoins(types.NewInt64Coin("uatom", 10)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := protov2.Marshal(&groupv1.MsgSubmitProposal{
+		GroupPolicyAddress: "cosmos1policy",
+		Proposers:          []string{"cosmos1proposer"},
+		Messages:           []*anypb.Any{{TypeUrl: send.TypeUrl, Value: send.Value}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mapped []string
+	sub := func(m *codec_types.Any, lg types.ABCIMessageLog) (structs.SubsetEvent, error) {
+		mapped = append(mapped, m.TypeUrl)
+		return (&Mapper{}).BankSendToSub(m.Value, lg)
+	}
+
+	se, err := (&Mapper{}).GroupSubmitProposalToSub(msg, types.ABCIMessageLog{}, sub)
+	if err != nil {
+		t.Fatalf("Mapper.GroupSubmitProposalToSub() error = %v", err)
+	}
+	if !reflect.DeepEqual(mapped, []string{"/cosmos.bank.v1beta1.MsgSend"}) {
+		t.Errorf("unexpected mapped messages %v", mapped)
+	}
+	if len(se.Sub) != 1 || se.Sub[0].Type[0] != "send" {
+		t.Errorf("unexpected Sub %+v", se.Sub)
+	}
+	if se.Node["proposer"][0].ID != "cosmos1proposer" || se.Additional["exec"][0] != "EXEC_UNSPECIFIED" {
+		t.Errorf("unexpected proposal %+v", se)
+	}
+}
@@ -0,0 +1,162 @@
+package mapper
+
+import (
+	"math/big"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/figment-networks/indexing-engine/structs"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
+)
+
+// Sources of balance changes
+const (
+	SourceTx         = "tx"
+	SourceBeginBlock = "begin_block"
+	SourceEndBlock   = "end_block"
+)
+
+// BalanceChange is a signed change of the account balance of the denom, negative for spent coins
+type BalanceChange struct {
+	Account string
+	Denom   string
+	Amount  *big.Int
+
+	// Event is the type of the event of the change (ie "coin_spent")
+	Event string
+	// Source is SourceTx, SourceBeginBlock or SourceEndBlock
+	Source string
+}
+
+// Ledger is the list of balance changes in the order of events
+type Ledger []BalanceChange
+
+// NewLedger creates ledger from the events of the source. Since cosmos-sdk v0.44 every balance change is
+// emitted as coin_spent or coin_received event and the transfer, burn and coinbase events only repeat
+// them, so these are used only when there are no coin_spent and coin_received events (earlier versions).
+// Events must not be merged by type (as in the message logs) to keep the order of changes.
+func NewLedger(events types.StringEvents, source string) (l Ledger, err error) {
+	coinEvents := hasCoinEvents(events)
+	for _, ev := range events {
+		switch ev.Type {
+		case "coin_spent":
+			err = l.add(ev, source, map[string]int{"spender": -1})
+		case "coin_received":
+			err = l.add(ev, source, map[string]int{"receiver": 1})
+		case "transfer":
+			if !coinEvents {
+				err = l.add(ev, source, map[string]int{"sender": -1, "recipient": 1})
+			}
+		case "burn":
+			if !coinEvents {
+				err = l.add(ev, source, map[string]int{"burner": -1})
+			}
+		case "coinbase":
+			if !coinEvents {
+				err = l.add(ev, source, map[string]int{"minter": 1})
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// hasCoinEvents checks if balance changes are emitted as coin_spent and coin_received events
+func hasCoinEvents(events types.StringEvents) bool {
+	for _, ev := range events {
+		if ev.Type == "coin_spent" || ev.Type == "coin_received" {
+			return true
+		}
+	}
+	return false
+}
+
+// TxLedger creates ledger from the events of the transaction
+func TxLedger(resp *types.TxResponse) (Ledger, error) {
+	events := make(types.StringEvents, 0, len(resp.Events))
+	for _, ev := range resp.Events {
+		events = append(events, types.StringifyEvent(ev))
+	}
+	return NewLedger(events, SourceTx)
+}
+
+// BlockLedger creates ledger from BeginBlock and EndBlock events of the block
+func BlockLedger(beginBlock, endBlock types.StringEvents) (Ledger, error) {
+	l, err := NewLedger(beginBlock, SourceBeginBlock)
+	if err != nil {
+		return nil, err
+	}
+	el, err := NewLedger(endBlock, SourceEndBlock)
+	if err != nil {
+		return nil, err
+	}
+	return append(l, el...), nil
+}
+
+// add adds changes of the event, signs are the signs of changes of the account attributes. Amount attribute
+// follows the account attributes, as in the events emitted by the bank module.
+func (l *Ledger) add(ev types.StringEvent, source string, signs map[string]int) error {
+	var accounts []string
+	for _, attr := range ev.Attributes {
+		if _, ok := signs[attr.Key]; ok {
+			accounts = append(accounts, attr.Key, attr.Value)
+			continue
+		}
+		if attr.Key != "amount" {
+			continue
+		}
+		if attr.Value == "" {
+			accounts = nil
+			continue
+		}
+		coins, err := types.ParseCoinsNormalized(attr.Value)
+		if err != nil {
+			return util.Errorf(util.ErrAmountParse, "[COSMOS-API] Error parsing amount '%s': %w", attr.Value, err)
+		}
+		for i := 0; i < len(accounts); i += 2 {
+			for _, c := range coins {
+				amount := c.Amount.BigInt()
+				if signs[accounts[i]] < 0 {
+					amount.Neg(amount)
+				}
+				*l = append(*l, BalanceChange{Account: accounts[i+1], Denom: c.Denom, Amount: amount, Event: ev.Type, Source: source})
+			}
+		}
+		accounts = nil
+	}
+	return nil
+}
+
+// Net returns the net change of balances by account and denom
+func (l Ledger) Net() map[string]map[string]*big.Int {
+	net := make(map[string]map[string]*big.Int)
+	for _, c := range l {
+		if net[c.Account] == nil {
+			net[c.Account] = make(map[string]*big.Int)
+		}
+		if net[c.Account][c.Denom] == nil {
+			net[c.Account][c.Denom] = new(big.Int)
+		}
+		net[c.Account][c.Denom].Add(net[c.Account][c.Denom], c.Amount)
+	}
+	return net
+}
+
+// AddToSub adds negative changes of the ledger to senders and positive ones to recipients of the SubsetEvent
+func (l Ledger) AddToSub(se *structs.SubsetEvent) {
+	for _, c := range l {
+		am := structs.TransactionAmount{
+			Currency: c.Denom,
+			Numeric:  new(big.Int).Abs(c.Amount),
+		}
+		am.Text = am.Numeric.String()
+		et := structs.EventTransfer{Account: structs.Account{ID: c.Account}, Amounts: []structs.TransactionAmount{am}}
+		if c.Amount.Sign() < 0 {
+			se.Sender = append(se.Sender, et)
+		} else {
+			se.Recipient = append(se.Recipient, et)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestTxLedger(t *testing.T) {
+	resp := &types.TxResponse{Events: types.Events{
+		types.NewEvent("coin_spent", types.NewAttribute("spender", "cosmos1sender"), types.NewAttribute("amount", "5uatom")),
+		types.NewEvent("coin_received", types.NewAttribute("receiver", "cosmos1feecollector"), types.NewAttribute("amount", "5uatom")),
+		types.NewEvent("transfer", types.NewAttribute("recipient", "cosmos1feecollector"), types.NewAttribute("sender", "cosmos1sender"), types.NewAttribute("amount", "5uatom")),
+		types.NewEvent("coin_spent", types.NewAttribute("spender", "cosmos1sender"), types.NewAttribute("amount", "100uatom,3uosmo")),
+		types.NewEvent("coin_received", types.NewAttribute("receiver", "cosmos1recipient"), types.NewAttribute("amount", "100uatom,3uosmo")),
+		types.NewEvent("transfer", types.NewAttribute("recipient", "cosmos1recipient"), types.NewAttribute("sender", "cosmos1sender"), types.NewAttribute("amount", "100uatom,3uosmo")),
+		types.NewEvent("coin_spent", types.NewAttribute("spender", "cosmos1recipient"), types.NewAttribute("amount", "1uosmo")),
+		types.NewEvent("burn", types.NewAttribute("burner", "cosmos1recipient"), types.NewAttribute("amount", "1uosmo")),
+	}.ToABCIEvents()}
+
+	l, err := TxLedger(resp)
+	if err != nil {
+		t.Fatalf("TxLedger() error = %v", err)
+	}
+	if len(l) != 7 {
+		t.Fatalf("TxLedger() expected 7 changes without repeated transfer and burn ones, got %+v", l)
+	}
+	if c := l[0]; c.Account != "cosmos1sender" || c.Denom != "uatom" || c.Amount.Int64() != -5 || c.Event != "coin_spent" || c.Source != SourceTx {
+		t.Errorf("TxLedger() unexpected first change %+v", c)
+	}
+
+	net := l.Net()
+	for account, want := range map[string]map[string]int64{
+		"cosmos1sender":       {"uatom": -105, "uosmo": -3},
+		"cosmos1feecollector": {"uatom": 5},
+		"cosmos1recipient":    {"uatom": 100, "uosmo": 2},
+	} {
+		for denom, amount := range want {
+			if got := net[account][denom]; got == nil || got.Int64() != amount {
+				t.Errorf("Ledger.Net() %s %s = %v, want %d", account, denom, got, amount)
+			}
+		}
+	}
+}
+
+func TestNewLedger_Legacy(t *testing.T) {
+	l, err := NewLedger(types.StringEvents{
+		{Type: "transfer", Attributes: []types.Attribute{
+			{Key: "recipient", Value: "cosmos1recipient"}, {Key: "sender", Value: "cosmos1sender"}, {Key: "amount", Value: "100uatom"},
+		}},
+	}, SourceEndBlock)
+	if err != nil {
+		t.Fatalf("NewLedger() error = %v", err)
+	}
+	if len(l) != 2 || l[0].Account != "cosmos1recipient" || l[0].Amount.Int64() != 100 || l[1].Account != "cosmos1sender" || l[1].Amount.Int64() != -100 {
+		t.Errorf("NewLedger() unexpected changes %+v", l)
+	}
+}
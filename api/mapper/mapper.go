@@ -1,5 +1,59 @@
 package mapper
 
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	auth "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
+)
+
 type Mapper struct {
 	UnbondedAddress string
+	BondedAddress   string
+
+	// Bech32Prefix is the account prefix of signer addresses, "cosmos" when empty
+	Bech32Prefix string
+	// FeeCollectorAddress is the recipient of fee transfers
+	FeeCollectorAddress string
+	// StakingDenom is the denom of amounts without denom in block events
+	StakingDenom string
+
+	// Upgrade is set by Registry.MapAt to the upgrade of the mapped message height, messages
+	// carried inside it (ie authz.MsgExec) are mapped at the same upgrade
+	Upgrade *util.Upgrade
+}
+
+// NewMapper creates Mapper with the staking pool addresses derived from the chain profile
+func NewMapper(p util.ChainProfile) (*Mapper, error) {
+	p = p.WithDefaults()
+	bonded, err := ModuleAddress(p.Bech32.Account, p.ModuleAccounts.BondedPool)
+	if err != nil {
+		return nil, err
+	}
+	unbonded, err := ModuleAddress(p.Bech32.Account, p.ModuleAccounts.NotBondedPool)
+	if err != nil {
+		return nil, err
+	}
+	feeCollector, err := ModuleAddress(p.Bech32.Account, auth.FeeCollectorName)
+	if err != nil {
+		return nil, err
+	}
+	return &Mapper{
+		UnbondedAddress:     unbonded,
+		BondedAddress:       bonded,
+		Bech32Prefix:        p.Bech32.Account,
+		FeeCollectorAddress: feeCollector,
+		StakingDenom:        p.StakingDenom,
+	}, nil
+}
+
+// ModuleAddress returns the bech32 address of the module account
+func ModuleAddress(bech32Prefix, moduleName string) (string, error) {
+	addr, err := bech32.ConvertAndEncode(bech32Prefix, auth.NewModuleAddress(moduleName))
+	if err != nil {
+		return "", fmt.Errorf("error encoding %s module address: %w", moduleName, err)
+	}
+	return addr, nil
 }
@@ -0,0 +1,69 @@
+package reward
+
+import (
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"go.uber.org/zap"
+
+	"github.com/figment-networks/ni-cosmoslib/api/mapper"
+	"github.com/figment-networks/ni-cosmoslib/util"
+)
+
+// PoolModules are names of the staking module accounts holding bonded and not bonded tokens
+type PoolModules struct {
+	Bonded    string
+	NotBonded string
+}
+
+// DefaultPoolModules are staking pool module names of cosmos-sdk
+var DefaultPoolModules = PoolModules{
+	Bonded:    staking.BondedPoolName,
+	NotBonded: staking.NotBondedPoolName,
+}
+
+// NewMapper creates Mapper deriving staking pool addresses from the bech32 prefix,
+// modules can be nil for the DefaultPoolModules
+func NewMapper(logger *zap.Logger, bech32Prefix, defaultCurrency string, modules *PoolModules) (*Mapper, error) {
+	pm := DefaultPoolModules
+	if modules != nil {
+		if modules.Bonded != "" {
+			pm.Bonded = modules.Bonded
+		}
+		if modules.NotBonded != "" {
+			pm.NotBonded = modules.NotBonded
+		}
+	}
+
+	bonded, err := mapper.ModuleAddress(bech32Prefix, pm.Bonded)
+	if err != nil {
+		return nil, err
+	}
+	notBonded, err := mapper.ModuleAddress(bech32Prefix, pm.NotBonded)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Mapper{
+		Logger:              logger,
+		DefaultCurrency:     defaultCurrency,
+		BondedTokensPool:    bonded,
+		NotBondedTokensPool: notBonded,
+	}, nil
+}
+
+// NewProfileMapper creates Mapper for the chain profile
+func NewProfileMapper(logger *zap.Logger, p util.ChainProfile) (*Mapper, error) {
+	p = p.WithDefaults()
+	return NewMapper(logger, p.Bech32.Account, p.StakingDenom, &PoolModules{
+		Bonded:    p.ModuleAccounts.BondedPool,
+		NotBonded: p.ModuleAccounts.NotBondedPool,
+	})
+}
+
+// NewChainMapper creates Mapper for the built-in chain profile (ie "osmosis")
+func NewChainMapper(logger *zap.Logger, chain string) (*Mapper, error) {
+	p, err := util.BuiltinChainProfile(chain)
+	if err != nil {
+		return nil, err
+	}
+	return NewProfileMapper(logger, p)
+}
@@ -0,0 +1,34 @@
+package reward
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestNewChainMapper(t *testing.T) {
+	tests := []struct {
+		chain         string
+		wantBonded    string
+		wantNotBonded string
+	}{
+		{chain: "cosmos", wantBonded: "cosmos1fl48vsnmsdzcv85q5d2q4z5ajdha8yu34mf0eh", wantNotBonded: "cosmos1tygms3xhhs3yv487phx3dw4a95jn7t7lpm470r"},
+		{chain: "osmosis", wantBonded: "osmo1fl48vsnmsdzcv85q5d2q4z5ajdha8yu3aq6l09", wantNotBonded: "osmo1tygms3xhhs3yv487phx3dw4a95jn7t7lfqxwe3"},
+		{chain: "kava", wantBonded: "kava1fl48vsnmsdzcv85q5d2q4z5ajdha8yu3fwaj0s", wantNotBonded: "kava1tygms3xhhs3yv487phx3dw4a95jn7t7lawprey"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.chain, func(t *testing.T) {
+			m, err := NewChainMapper(zaptest.NewLogger(t), tt.chain)
+			if err != nil {
+				t.Fatalf("NewChainMapper() error = %v", err)
+			}
+			if m.BondedTokensPool != tt.wantBonded || m.NotBondedTokensPool != tt.wantNotBonded {
+				t.Errorf("NewChainMapper() pools = %s %s, want %s %s", m.BondedTokensPool, m.NotBondedTokensPool, tt.wantBonded, tt.wantNotBonded)
+			}
+		})
+	}
+
+	if _, err := NewChainMapper(zaptest.NewLogger(t), "unknown"); err == nil {
+		t.Error("NewChainMapper() expected error for unknown chain")
+	}
+}
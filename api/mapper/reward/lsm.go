@@ -0,0 +1,164 @@
+package reward
+
+import (
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/figment-networks/indexing-engine/proto/rewstruct"
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/figment-networks/ni-cosmoslib/api/mapper"
+	"github.com/figment-networks/ni-cosmoslib/util"
+)
+
+// MsgTokenizeShares transforms staking.MsgTokenizeShares (LSM) sdk messages and related events to RewardTx.
+// Rewards of the tokenized delegation are claimed automatically.
+func (m *Mapper) MsgTokenizeShares(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
+	wvc := &mapper.MsgTokenizeShares{}
+	if err := proto.Unmarshal(msg, wvc); err != nil {
+		return rev, util.Errorf(util.ErrMalformedMessage, "not a staking type: %w", err)
+	}
+
+	rev = &rewstruct.RewardTx{
+		Type:         "MsgTokenizeShares",
+		Delegator:    wvc.DelegatorAddress,
+		ValidatorSrc: wvc.ValidatorAddress,
+	}
+
+	am, err := m.amounts([]string{wvc.Amount.String()})
+	if err != nil {
+		return rev, err
+	}
+	rev.Amounts = am
+
+	err = m.transferRewards(rev, lg, wvc.ValidatorAddress)
+	return rev, err
+}
+
+// MsgRedeemTokensForShares transforms staking.MsgRedeemTokensForShares (LSM) sdk messages and related events to RewardTx
+func (m *Mapper) MsgRedeemTokensForShares(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
+	wvc := &mapper.MsgRedeemTokensForShares{}
+	if err := proto.Unmarshal(msg, wvc); err != nil {
+		return rev, util.Errorf(util.ErrMalformedMessage, "not a staking type: %w", err)
+	}
+
+	rev = &rewstruct.RewardTx{
+		Type:         "MsgRedeemTokensForShares",
+		Delegator:    wvc.DelegatorAddress,
+		ValidatorDst: mapper.ShareTokenValidator(wvc.Amount.Denom),
+	}
+	for _, ev := range lg.GetEvents() {
+		if ev.GetType() != "redeem_shares" {
+			continue
+		}
+		for _, attr := range ev.GetAttributes() {
+			if attr.Key == "validator" {
+				rev.ValidatorDst = attr.Value
+			}
+		}
+	}
+
+	am, err := m.amounts([]string{wvc.Amount.String()})
+	if err != nil {
+		return rev, err
+	}
+	rev.Amounts = am
+
+	err = m.transferRewards(rev, lg, rev.ValidatorDst)
+	return rev, err
+}
+
+// MsgTransferTokenizeShareRecord transforms staking.MsgTransferTokenizeShareRecord (LSM) sdk messages and related events to RewardTx
+func (m *Mapper) MsgTransferTokenizeShareRecord(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
+	wvc := &mapper.MsgTransferTokenizeShareRecord{}
+	if err := proto.Unmarshal(msg, wvc); err != nil {
+		return rev, util.Errorf(util.ErrMalformedMessage, "not a staking type: %w", err)
+	}
+
+	return &rewstruct.RewardTx{
+		Type:      "MsgTransferTokenizeShareRecord",
+		Delegator: wvc.Sender,
+	}, nil
+}
+
+// MsgValidatorBond transforms staking.MsgValidatorBond (LSM) sdk messages and related events to RewardTx
+func (m *Mapper) MsgValidatorBond(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
+	wvc := &mapper.MsgValidatorBond{}
+	if err := proto.Unmarshal(msg, wvc); err != nil {
+		return rev, util.Errorf(util.ErrMalformedMessage, "not a staking type: %w", err)
+	}
+
+	rev = &rewstruct.RewardTx{
+		Type:         "MsgValidatorBond",
+		Delegator:    wvc.DelegatorAddress,
+		ValidatorDst: wvc.ValidatorAddress,
+	}
+
+	err = m.transferRewards(rev, lg, wvc.ValidatorAddress)
+	return rev, err
+}
+
+// MsgCancelUnbondingDelegation transforms staking.MsgCancelUnbondingDelegation sdk messages and related events to RewardTx
+func (m *Mapper) MsgCancelUnbondingDelegation(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
+	wvc := &mapper.MsgCancelUnbondingDelegation{}
+	if err := proto.Unmarshal(msg, wvc); err != nil {
+		return rev, util.Errorf(util.ErrMalformedMessage, "not a staking type: %w", err)
+	}
+
+	rev = &rewstruct.RewardTx{
+		Type:         "MsgCancelUnbondingDelegation",
+		Delegator:    wvc.DelegatorAddress,
+		ValidatorDst: wvc.ValidatorAddress,
+	}
+
+	am, err := m.amounts([]string{wvc.Amount.String()})
+	if err != nil {
+		return rev, err
+	}
+	rev.Amounts = am
+
+	err = m.transferRewards(rev, lg, wvc.ValidatorAddress)
+	return rev, err
+}
+
+// transferRewards adds transfers from the first transfer event as rewards of the validator, same as for MsgDelegate.
+// Transfers to the staking pools and LSM share tokens of the validator are skipped.
+func (m *Mapper) transferRewards(rev *rewstruct.RewardTx, lg types.ABCIMessageLog, validator string) error {
+	for _, ev := range lg.GetEvents() {
+		if ev.GetType() != "transfer" {
+			continue
+		}
+		parsed, err := m.groupEvents(ev)
+		if err != nil {
+			return err
+		}
+		for _, p := range parsed {
+			if p["recipient"] == "" || p["recipient"] == m.BondedTokensPool || p["recipient"] == m.NotBondedTokensPool {
+				continue
+			}
+			var amounts []string
+			for _, amt := range strings.Split(p["amount"], ",") {
+				if sv := mapper.ShareTokenValidator(strings.TrimLeft(amt, "0123456789")); sv != "" && strings.EqualFold(sv, validator) {
+					continue
+				}
+				amounts = append(amounts, amt)
+			}
+			if len(amounts) == 0 {
+				continue
+			}
+			am, err := m.amounts(amounts)
+			if err != nil {
+				return err
+			}
+			if rev.Delegator != p["recipient"] {
+				rev.RewardRecipients = append(rev.RewardRecipients, p["recipient"])
+			}
+			rev.Rewards = append(rev.Rewards, &rewstruct.RewardAmount{
+				Amounts:   am,
+				Validator: validator,
+			})
+		}
+		break
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package reward
+
+import (
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/figment-networks/indexing-engine/proto/rewstruct"
+
+	"github.com/figment-networks/ni-cosmoslib/api/osmosis_mapper"
+)
+
+// MsgLockTokens transforms osmosis lockup.MsgLockTokens sdk messages and related events to RewardTx
+func (m *Mapper) MsgLockTokens(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
+	owner, coins, err := osmosis_mapper.LockTokens(msg)
+	if err != nil {
+		return rev, err
+	}
+
+	rev = &rewstruct.RewardTx{
+		Type:      "MsgLockTokens",
+		Delegator: owner,
+	}
+	for _, coin := range coins {
+		am, err := m.amounts([]string{coin.String()})
+		if err != nil {
+			return rev, err
+		}
+		rev.Amounts = append(rev.Amounts, am...)
+	}
+
+	err = m.withdrawnRewards(rev, lg, "", false)
+	return rev, err
+}
+
+// MsgBeginUnlocking transforms osmosis lockup.MsgBeginUnlocking sdk messages and related events to RewardTx
+func (m *Mapper) MsgBeginUnlocking(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
+	owner, coins, err := osmosis_mapper.BeginUnlocking(msg)
+	if err != nil {
+		return rev, err
+	}
+
+	rev = &rewstruct.RewardTx{
+		Type:      "MsgBeginUnlocking",
+		Delegator: owner,
+	}
+	for _, coin := range coins {
+		am, err := m.amounts([]string{coin.String()})
+		if err != nil {
+			return rev, err
+		}
+		rev.Amounts = append(rev.Amounts, am...)
+	}
+
+	err = m.withdrawnRewards(rev, lg, "", false)
+	return rev, err
+}
+
+// MsgSuperfluidDelegate transforms osmosis superfluid.MsgSuperfluidDelegate sdk messages and related events to RewardTx
+func (m *Mapper) MsgSuperfluidDelegate(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
+	sender, validator, err := osmosis_mapper.Superfluid(msg)
+	if err != nil {
+		return rev, err
+	}
+
+	rev = &rewstruct.RewardTx{
+		Type:         "MsgSuperfluidDelegate",
+		Delegator:    sender,
+		ValidatorDst: validator,
+	}
+
+	err = m.withdrawnRewards(rev, lg, validator, true)
+	return rev, err
+}
+
+// MsgSuperfluidUndelegate transforms osmosis superfluid.MsgSuperfluidUndelegate sdk messages and related events to RewardTx
+func (m *Mapper) MsgSuperfluidUndelegate(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
+	return m.superfluidUnbond("MsgSuperfluidUndelegate", msg, lg)
+}
+
+// MsgSuperfluidUnbondLock transforms osmosis superfluid.MsgSuperfluidUnbondLock sdk messages and related events to RewardTx
+func (m *Mapper) MsgSuperfluidUnbondLock(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
+	return m.superfluidUnbond("MsgSuperfluidUnbondLock", msg, lg)
+}
+
+func (m *Mapper) superfluidUnbond(msgType string, msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
+	sender, _, err := osmosis_mapper.Superfluid(msg)
+	if err != nil {
+		return rev, err
+	}
+
+	rev = &rewstruct.RewardTx{
+		Type:      msgType,
+		Delegator: sender,
+	}
+
+	if err = m.withdrawnRewards(rev, lg, "", true); err != nil {
+		return rev, err
+	}
+	// the message has only the lock id, the validator is known from the rewards withdrawn by the intermediary account
+	for _, r := range osmosis_mapper.WithdrawnRewards(lg) {
+		if r.Validator != "" {
+			rev.ValidatorSrc = r.Validator
+			break
+		}
+	}
+	return rev, nil
+}
+
+// withdrawnRewards adds rewards from withdraw_rewards events of the delegator as claimed, validator is used
+// when it's not present in the event. Superfluid rewards withdrawn by the intermediary account are skipped.
+func (m *Mapper) withdrawnRewards(rev *rewstruct.RewardTx, lg types.ABCIMessageLog, validator string, superfluid bool) error {
+	own, _ := osmosis_mapper.SenderRewards(osmosis_mapper.WithdrawnRewards(lg), rev.Delegator, superfluid)
+	for _, r := range own {
+		am, err := m.amounts(strings.Split(r.Amount, ","))
+		if err != nil {
+			return err
+		}
+		reward := &rewstruct.RewardAmount{
+			Amounts:   am,
+			Validator: r.Validator,
+		}
+		if reward.Validator == "" {
+			reward.Validator = validator
+		}
+		rev.Rewards = append(rev.Rewards, reward)
+	}
+	return nil
+}
@@ -29,11 +29,16 @@ cosmos NotBondedTokensPool (undelegate) "cosmos1tygms3xhhs3yv487phx3dw4a95jn7t7l
 kava BondedTokensPool (delegate) "kava1fl48vsnmsdzcv85q5d2q4z5ajdha8yu3fwaj0s"       https://www.mintscan.io/kava/txs/17B3C52FB4F876EC53FB18B4B2592F47B2CFB81BDD76E375F166036FB9DE59AA
 kava NotBondedTokensPool (undelegate) "kava1tygms3xhhs3yv487phx3dw4a95jn7t7lawprey"  https://www.mintscan.io/kava/txs/B06C50A36FB3F01584F190FB68BA28FF9C3CCC2E286CBDDECFBD5A6D3DD7C1A6
 */
+// Mapper maps staking and distribution messages to RewardTx, use NewMapper or NewChainMapper to
+// derive the pool addresses above from the bech32 prefix instead of setting them manually
 type Mapper struct {
 	Logger              *zap.Logger
 	DefaultCurrency     string
 	BondedTokensPool    string
 	NotBondedTokensPool string
+
+	// upgrade is set by ParseRewardEventAt to the upgrade of the parsed transaction height
+	upgrade *util.Upgrade
 }
 
 var currencyRegexp = regexp.MustCompile(`^\d+$`)
@@ -50,12 +55,23 @@ func ValidatorFromTx(tx *rewstruct.RewardTx) string {
 		validator = tx.ValidatorDst
 	case "MsgBeginRedelegate":
 		validator = tx.ValidatorSrc
+	case "MsgTokenizeShares":
+		validator = tx.ValidatorSrc
+	case "MsgRedeemTokensForShares", "MsgValidatorBond", "MsgCancelUnbondingDelegation":
+		validator = tx.ValidatorDst
+	case "MsgSuperfluidDelegate":
+		validator = tx.ValidatorDst
+	case "MsgSuperfluidUndelegate", "MsgSuperfluidUnbondLock":
+		validator = tx.ValidatorSrc
 	}
 	return validator
 }
 
 // ParseRewardEvent converts a cosmos event from the log to a Subevent type and adds it to the provided RewardEvent struct
 func ParseRewardEvent(module, msgType string, raw []byte, lg types.ABCIMessageLog, ma *Mapper) (rev *rewstruct.RewardTx, err error) {
+	defer func() {
+		err = util.WithMessage(err, module, msgType, "", int(lg.MsgIndex))
+	}()
 
 	switch module {
 	case "distribution":
@@ -81,17 +97,78 @@ func ParseRewardEvent(module, msgType string, raw []byte, lg types.ABCIMessageLo
 			return ma.MsgEditValidator(raw, lg)
 		case "MsgCreateValidator":
 			return ma.MsgCreateValidator(raw, lg)
+		case "MsgTokenizeShares":
+			return ma.MsgTokenizeShares(raw, lg)
+		case "MsgRedeemTokensForShares":
+			return ma.MsgRedeemTokensForShares(raw, lg)
+		case "MsgTransferTokenizeShareRecord":
+			return ma.MsgTransferTokenizeShareRecord(raw, lg)
+		case "MsgValidatorBond":
+			return ma.MsgValidatorBond(raw, lg)
+		case "MsgCancelUnbondingDelegation":
+			return ma.MsgCancelUnbondingDelegation(raw, lg)
+		}
+	case "lockup":
+		switch msgType {
+		case "MsgLockTokens":
+			return ma.MsgLockTokens(raw, lg)
+		case "MsgBeginUnlocking":
+			return ma.MsgBeginUnlocking(raw, lg)
+		}
+	case "superfluid":
+		switch msgType {
+		case "MsgSuperfluidDelegate":
+			return ma.MsgSuperfluidDelegate(raw, lg)
+		case "MsgSuperfluidUndelegate":
+			return ma.MsgSuperfluidUndelegate(raw, lg)
+		case "MsgSuperfluidUnbondLock":
+			return ma.MsgSuperfluidUnbondLock(raw, lg)
 		}
 
 	}
 	return
 }
 
+// ParseRewardEventAt is ParseRewardEvent for the message of a transaction at the height. The sdk version
+// of the height decides whether the message exists (see rewardMessagesSince) and whether staking amounts
+// without denom get the default currency (only before v0.43), everything else is parsed as in ParseRewardEvent.
+func ParseRewardEventAt(module, msgType string, raw []byte, lg types.ABCIMessageLog, ma *Mapper, height uint64, schedule util.UpgradeSchedule) (rev *rewstruct.RewardTx, err error) {
+	if ma == nil {
+		return nil, util.WithMessage(fmt.Errorf("problem with reward event %s - %s: nil mapper", module, msgType), module, msgType, "", int(lg.MsgIndex))
+	}
+	u := schedule.At(height)
+	if since, ok := rewardMessagesSince[msgType]; ok && !u.SDKAtLeast(since) {
+		err = fmt.Errorf("problem with reward event %s - %s (sdk %s): %w", module, msgType, u.SDKVersion, util.ErrUnknownMessageType)
+		return nil, util.WithMessage(err, module, msgType, "", int(lg.MsgIndex))
+	}
+	m := *ma
+	m.upgrade = &u
+	return ParseRewardEvent(module, msgType, raw, lg, &m)
+}
+
+// rewardMessagesSince are minimal cosmos-sdk versions of messages that don't exist in the earlier ones
+var rewardMessagesSince = map[string]string{
+	"MsgCancelUnbondingDelegation": "v0.46",
+}
+
+// amounts transforms amounts to Amount structure. Staking events of cosmos-sdk before v0.43 have amounts
+// without denom, the default currency is added to them unless the upgrade says the height is of a later version.
+func (m *Mapper) amounts(amounts []string) ([]*rewstruct.Amount, error) {
+	if m.upgrade != nil && m.upgrade.SDKVersion != "" && m.upgrade.SDKAtLeast("v0.43") {
+		for _, amt := range amounts {
+			if currencyRegexp.MatchString(amt) {
+				return nil, util.Errorf(util.ErrAmountParse, "[COSMOS-API] Error parsing amount '%s': missing denom (sdk %s)", amt, m.upgrade.SDKVersion)
+			}
+		}
+	}
+	return fAmounts(m.DefaultCurrency, amounts)
+}
+
 // MsgWithdrawValidatorCommission transforms distribution.MsgWithdrawValidatorCommission sdk messages and related events to RewardTx
 func (m *Mapper) MsgWithdrawValidatorCommission(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
 	wvc := &distribution.MsgWithdrawValidatorCommission{}
 	if err := proto.Unmarshal(msg, wvc); err != nil {
-		return rev, fmt.Errorf("not a distribution type: %w", err)
+		return rev, util.Errorf(util.ErrMalformedMessage, "not a distribution type: %w", err)
 	}
 
 	rev = &rewstruct.RewardTx{
@@ -106,7 +183,7 @@ func (m *Mapper) MsgWithdrawValidatorCommission(msg []byte, lg types.ABCIMessage
 				return rev, err
 			}
 			if val, ok := parsed[0]["amount"]; ok {
-				am, err := fAmounts(m.DefaultCurrency, strings.Split(val, ","))
+				am, err := m.amounts(strings.Split(val, ","))
 				if err != nil {
 					return rev, err
 				}
@@ -123,7 +200,7 @@ func (m *Mapper) MsgWithdrawValidatorCommission(msg []byte, lg types.ABCIMessage
 func (m *Mapper) MsgWithdrawDelegatorReward(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
 	wvc := &distribution.MsgWithdrawDelegatorReward{}
 	if err := proto.Unmarshal(msg, wvc); err != nil {
-		return rev, fmt.Errorf("not a distribution type: %w", err)
+		return rev, util.Errorf(util.ErrMalformedMessage, "not a distribution type: %w", err)
 	}
 
 	rev = &rewstruct.RewardTx{
@@ -140,7 +217,7 @@ func (m *Mapper) MsgWithdrawDelegatorReward(msg []byte, lg types.ABCIMessageLog)
 			}
 			for _, p := range parsed {
 
-				am, err := fAmounts(m.DefaultCurrency, strings.Split(p["amount"], ","))
+				am, err := m.amounts(strings.Split(p["amount"], ","))
 				if err != nil {
 					return rev, err
 				}
@@ -164,7 +241,7 @@ func (m *Mapper) MsgWithdrawDelegatorReward(msg []byte, lg types.ABCIMessageLog)
 func (m *Mapper) MsgUndelegate(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
 	wvc := &staking.MsgUndelegate{}
 	if err := proto.Unmarshal(msg, wvc); err != nil {
-		return rev, fmt.Errorf("not a distribution type: %w", err)
+		return rev, util.Errorf(util.ErrMalformedMessage, "not a distribution type: %w", err)
 	}
 
 	rev = &rewstruct.RewardTx{
@@ -184,7 +261,7 @@ func (m *Mapper) MsgUndelegate(msg []byte, lg types.ABCIMessageLog) (rev *rewstr
 						return rev, err
 					}
 					if val, ok := parsed[0]["amount"]; ok {
-						am, err := fAmounts(m.DefaultCurrency, strings.Split(val, ","))
+						am, err := m.amounts(strings.Split(val, ","))
 						if err != nil {
 							return rev, err
 						}
@@ -200,7 +277,7 @@ func (m *Mapper) MsgUndelegate(msg []byte, lg types.ABCIMessageLog) (rev *rewstr
 						if m.NotBondedTokensPool != "" && p["recipient"] == m.NotBondedTokensPool {
 							continue
 						}
-						am, err := fAmounts(m.DefaultCurrency, strings.Split(p["amount"], ","))
+						am, err := m.amounts(strings.Split(p["amount"], ","))
 						if err != nil {
 							return rev, err
 						}
@@ -226,7 +303,7 @@ func (m *Mapper) MsgUndelegate(msg []byte, lg types.ABCIMessageLog) (rev *rewstr
 func (m *Mapper) MsgDelegate(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
 	wvc := &staking.MsgDelegate{}
 	if err := proto.Unmarshal(msg, wvc); err != nil {
-		return rev, fmt.Errorf("not a distribution type: %w", err)
+		return rev, util.Errorf(util.ErrMalformedMessage, "not a distribution type: %w", err)
 	}
 
 	rev = &rewstruct.RewardTx{
@@ -246,7 +323,7 @@ func (m *Mapper) MsgDelegate(msg []byte, lg types.ABCIMessageLog) (rev *rewstruc
 						return rev, err
 					}
 					if val, ok := parsed[0]["amount"]; ok {
-						am, err := fAmounts(m.DefaultCurrency, strings.Split(val, ","))
+						am, err := m.amounts(strings.Split(val, ","))
 						if err != nil {
 							return rev, err
 						}
@@ -262,7 +339,7 @@ func (m *Mapper) MsgDelegate(msg []byte, lg types.ABCIMessageLog) (rev *rewstruc
 						if m.BondedTokensPool != "" && p["recipient"] == m.BondedTokensPool {
 							continue
 						}
-						am, err := fAmounts(m.DefaultCurrency, strings.Split(p["amount"], ","))
+						am, err := m.amounts(strings.Split(p["amount"], ","))
 						if err != nil {
 							return rev, err
 						}
@@ -288,7 +365,7 @@ func (m *Mapper) MsgDelegate(msg []byte, lg types.ABCIMessageLog) (rev *rewstruc
 func (m *Mapper) MsgBeginRedelegate(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
 	wvc := &staking.MsgBeginRedelegate{}
 	if err := proto.Unmarshal(msg, wvc); err != nil {
-		return rev, fmt.Errorf("not a distribution type: %w", err)
+		return rev, util.Errorf(util.ErrMalformedMessage, "not a distribution type: %w", err)
 	}
 
 	rev = &rewstruct.RewardTx{
@@ -308,7 +385,7 @@ func (m *Mapper) MsgBeginRedelegate(msg []byte, lg types.ABCIMessageLog) (rev *r
 						return rev, err
 					}
 					if val, ok := parsed[0]["amount"]; ok {
-						am, err := fAmounts(m.DefaultCurrency, strings.Split(val, ","))
+						am, err := m.amounts(strings.Split(val, ","))
 						if err != nil {
 							return rev, err
 						}
@@ -324,7 +401,7 @@ func (m *Mapper) MsgBeginRedelegate(msg []byte, lg types.ABCIMessageLog) (rev *r
 						if m.BondedTokensPool != "" && p["recipient"] == m.BondedTokensPool {
 							continue
 						}
-						am, err := fAmounts(m.DefaultCurrency, strings.Split(p["amount"], ","))
+						am, err := m.amounts(strings.Split(p["amount"], ","))
 						if err != nil {
 							return rev, err
 						}
@@ -440,7 +517,7 @@ func (m *Mapper) PostMsgBeginRedelegate(rev *rewstruct.RewardTx, dels []cosmosgr
 func (m *Mapper) MsgEditValidator(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
 	wvc := &staking.MsgEditValidator{}
 	if err := proto.Unmarshal(msg, wvc); err != nil {
-		return rev, fmt.Errorf("not a distribution type: %w", err)
+		return rev, util.Errorf(util.ErrMalformedMessage, "not a distribution type: %w", err)
 	}
 
 	rev = &rewstruct.RewardTx{
@@ -455,7 +532,7 @@ func (m *Mapper) MsgEditValidator(msg []byte, lg types.ABCIMessageLog) (rev *rew
 func (m *Mapper) MsgCreateValidator(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
 	wvc := &staking.MsgCreateValidator{}
 	if err := proto.Unmarshal(msg, wvc); err != nil {
-		return rev, fmt.Errorf("not a distribution type: %w", err)
+		return rev, util.Errorf(util.ErrMalformedMessage, "not a distribution type: %w", err)
 	}
 
 	rev = &rewstruct.RewardTx{
@@ -471,7 +548,7 @@ func (m *Mapper) MsgCreateValidator(msg []byte, lg types.ABCIMessageLog) (rev *r
 func (m *Mapper) MsgSetWithdrawAddress(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
 	wvc := &distribution.MsgSetWithdrawAddress{}
 	if err := proto.Unmarshal(msg, wvc); err != nil {
-		return rev, fmt.Errorf("not a distribution type: %w", err)
+		return rev, util.Errorf(util.ErrMalformedMessage, "not a distribution type: %w", err)
 	}
 
 	rev = &rewstruct.RewardTx{
@@ -487,7 +564,7 @@ func (m *Mapper) MsgSetWithdrawAddress(msg []byte, lg types.ABCIMessageLog) (rev
 func (m *Mapper) MsgFundCommunityPool(msg []byte, lg types.ABCIMessageLog) (rev *rewstruct.RewardTx, err error) {
 	wvc := &distribution.MsgFundCommunityPool{}
 	if err := proto.Unmarshal(msg, wvc); err != nil {
-		return rev, fmt.Errorf("not a distribution type: %w", err)
+		return rev, util.Errorf(util.ErrMalformedMessage, "not a distribution type: %w", err)
 	}
 
 	rev = &rewstruct.RewardTx{
@@ -519,7 +596,7 @@ func fAmounts(defaultCurrency string, amounts []string) (am []*rewstruct.Amount,
 			c, exp, coinErr = util.GetCoin(amt)
 		}
 		if coinErr != nil {
-			return nil, fmt.Errorf("[COSMOS-API] Error parsing amount '%s': %s ", amt, coinErr)
+			return nil, util.Errorf(util.ErrAmountParse, "[COSMOS-API] Error parsing amount '%s': %s ", amt, coinErr)
 		}
 
 		attrAmt.Numeric = c.Bytes()
@@ -595,7 +672,7 @@ func (m *Mapper) groupEvents(ev types.StringEvent) (result []map[string]string,
 		result = append(result, emap)
 	}
 	if len(result) == 0 {
-		return result, fmt.Errorf("missing events type: %s", etype)
+		return result, util.Errorf(util.ErrMissingEvent, "missing events type: %s", etype)
 	}
 
 	return result, nil
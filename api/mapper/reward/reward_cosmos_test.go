@@ -1,13 +1,17 @@
 package reward
 
 import (
+	"errors"
 	"math/big"
 	"reflect"
 	"testing"
 
 	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/figment-networks/indexing-engine/proto/rewstruct"
+	"github.com/figment-networks/ni-cosmoslib/api/mapper"
 	"github.com/figment-networks/ni-cosmoslib/client/cosmosgrpc"
+	"github.com/figment-networks/ni-cosmoslib/util"
+	"github.com/gogo/protobuf/proto"
 	"go.uber.org/zap/zaptest"
 )
 
@@ -355,3 +359,123 @@ func TestMapper_MsgWithdrawValidatorCommission_Cosmos(t *testing.T) {
 		})
 	}
 }
+
+func TestMapper_MsgTokenizeShares_Cosmos(t *testing.T) {
+	msg, err := proto.Marshal(&mapper.MsgTokenizeShares{
+		DelegatorAddress:    "cosmos1mc0mxsdgsyjepsqetw3w5a459zj64k7akuhdu4",
+		ValidatorAddress:    "cosmosvaloper157v7tczs40axfgejp2m43kwuzqe0wsy0rv8puv",
+		Amount:              types.NewInt64Coin("uatom", 140000),
+		TokenizedShareOwner: "cosmos1mc0mxsdgsyjepsqetw3w5a459zj64k7akuhdu4",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lg := types.ABCIMessageLog{MsgIndex: 0, Log: "", Events: []types.StringEvent{
+		{Type: "message", Attributes: []types.Attribute{{Key: "action", Value: "/cosmos.staking.v1beta1.MsgTokenizeShares"}, {Key: "module", Value: "staking"}, {Key: "sender", Value: "cosmos1mc0mxsdgsyjepsqetw3w5a459zj64k7akuhdu4"}}},
+		{Type: "transfer", Attributes: []types.Attribute{
+			{Key: "recipient", Value: "cosmos1mc0mxsdgsyjepsqetw3w5a459zj64k7akuhdu4"}, {Key: "sender", Value: "cosmos1jv65s3grqf6v6jl3dp4t6c9t9rk99cd88lyufl"}, {Key: "amount", Value: "25uatom"},
+			{Key: "recipient", Value: "cosmos1mc0mxsdgsyjepsqetw3w5a459zj64k7akuhdu4"}, {Key: "sender", Value: "cosmos1m3h30wlvsf8llruxtpukdvsy0km2kum8g38c8q"}, {Key: "amount", Value: "140000cosmosvaloper157v7tczs40axfgejp2m43kwuzqe0wsy0rv8puv/12"},
+		}},
+	}}
+
+	m := &Mapper{
+		Logger:              zaptest.NewLogger(t),
+		DefaultCurrency:     "uatom",
+		BondedTokensPool:    "cosmos1fl48vsnmsdzcv85q5d2q4z5ajdha8yu34mf0eh",
+		NotBondedTokensPool: "cosmos1tygms3xhhs3yv487phx3dw4a95jn7t7lpm470r",
+	}
+	gotRev, err := ParseRewardEvent("staking", "MsgTokenizeShares", msg, lg, m)
+	if err != nil {
+		t.Fatalf("Mapper.MsgTokenizeShares() error = %v", err)
+	}
+
+	wantRev := &rewstruct.RewardTx{
+		Type:         "MsgTokenizeShares",
+		ValidatorSrc: "cosmosvaloper157v7tczs40axfgejp2m43kwuzqe0wsy0rv8puv",
+		Delegator:    "cosmos1mc0mxsdgsyjepsqetw3w5a459zj64k7akuhdu4",
+		Amounts:      []*rewstruct.Amount{{Text: "140000uatom", Currency: "uatom", Numeric: []byte("\x02\"\xe0")}},
+		Rewards:      []*rewstruct.RewardAmount{{Amounts: []*rewstruct.Amount{{Text: "25uatom", Currency: "uatom", Numeric: []byte("\x19")}}, Validator: "cosmosvaloper157v7tczs40axfgejp2m43kwuzqe0wsy0rv8puv"}},
+	}
+	if !reflect.DeepEqual(gotRev, wantRev) {
+		t.Errorf("Mapper.MsgTokenizeShares() = %v, want %v", gotRev, wantRev)
+	}
+}
+
+func TestMapper_MsgCancelUnbondingDelegation_Cosmos(t *testing.T) {
+	msg, err := proto.Marshal(&mapper.MsgCancelUnbondingDelegation{
+		DelegatorAddress: "cosmos1mc0mxsdgsyjepsqetw3w5a459zj64k7akuhdu4",
+		ValidatorAddress: "cosmosvaloper157v7tczs40axfgejp2m43kwuzqe0wsy0rv8puv",
+		Amount:           types.NewInt64Coin("uatom", 140000),
+		CreationHeight:   14000000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lg := types.ABCIMessageLog{MsgIndex: 0, Log: "", Events: []types.StringEvent{
+		{Type: "cancel_unbonding_delegation", Attributes: []types.Attribute{{Key: "validator", Value: "cosmosvaloper157v7tczs40axfgejp2m43kwuzqe0wsy0rv8puv"}, {Key: "delegator", Value: "cosmos1mc0mxsdgsyjepsqetw3w5a459zj64k7akuhdu4"}, {Key: "amount", Value: "140000uatom"}, {Key: "creation_height", Value: "14000000"}}},
+		{Type: "transfer", Attributes: []types.Attribute{
+			{Key: "recipient", Value: "cosmos1mc0mxsdgsyjepsqetw3w5a459zj64k7akuhdu4"}, {Key: "sender", Value: "cosmos1jv65s3grqf6v6jl3dp4t6c9t9rk99cd88lyufl"}, {Key: "amount", Value: "25uatom"},
+			{Key: "recipient", Value: "cosmos1fl48vsnmsdzcv85q5d2q4z5ajdha8yu34mf0eh"}, {Key: "sender", Value: "cosmos1tygms3xhhs3yv487phx3dw4a95jn7t7lpm470r"}, {Key: "amount", Value: "140000uatom"},
+		}},
+	}}
+
+	m := &Mapper{
+		Logger:              zaptest.NewLogger(t),
+		DefaultCurrency:     "uatom",
+		BondedTokensPool:    "cosmos1fl48vsnmsdzcv85q5d2q4z5ajdha8yu34mf0eh",
+		NotBondedTokensPool: "cosmos1tygms3xhhs3yv487phx3dw4a95jn7t7lpm470r",
+	}
+	gotRev, err := ParseRewardEvent("staking", "MsgCancelUnbondingDelegation", msg, lg, m)
+	if err != nil {
+		t.Fatalf("Mapper.MsgCancelUnbondingDelegation() error = %v", err)
+	}
+
+	wantRev := &rewstruct.RewardTx{
+		Type:         "MsgCancelUnbondingDelegation",
+		ValidatorDst: "cosmosvaloper157v7tczs40axfgejp2m43kwuzqe0wsy0rv8puv",
+		Delegator:    "cosmos1mc0mxsdgsyjepsqetw3w5a459zj64k7akuhdu4",
+		Amounts:      []*rewstruct.Amount{{Text: "140000uatom", Currency: "uatom", Numeric: []byte("\x02\"\xe0")}},
+		Rewards:      []*rewstruct.RewardAmount{{Amounts: []*rewstruct.Amount{{Text: "25uatom", Currency: "uatom", Numeric: []byte("\x19")}}, Validator: "cosmosvaloper157v7tczs40axfgejp2m43kwuzqe0wsy0rv8puv"}},
+	}
+	if !reflect.DeepEqual(gotRev, wantRev) {
+		t.Errorf("Mapper.MsgCancelUnbondingDelegation() = %v, want %v", gotRev, wantRev)
+	}
+
+	// the message exists since cosmos-sdk v0.46
+	schedule := util.UpgradeSchedule{{Height: 100, SDKVersion: "v0.45.16"}, {Height: 200, SDKVersion: "v0.46.0"}}
+	if _, err := ParseRewardEventAt("staking", "MsgCancelUnbondingDelegation", msg, lg, m, 150, schedule); !errors.Is(err, util.ErrUnknownMessageType) {
+		t.Errorf("ParseRewardEventAt() v0.45 error = %v, want %v", err, util.ErrUnknownMessageType)
+	}
+	if _, err := ParseRewardEventAt("staking", "MsgCancelUnbondingDelegation", msg, lg, m, 250, schedule); err != nil {
+		t.Errorf("ParseRewardEventAt() v0.46 error = %v", err)
+	}
+	if _, err := ParseRewardEventAt("staking", "MsgCancelUnbondingDelegation", msg, lg, nil, 250, schedule); err == nil {
+		t.Errorf("ParseRewardEventAt() nil mapper expected error")
+	}
+}
+
+func TestMapper_amounts_Upgrades(t *testing.T) {
+	schedule := util.UpgradeSchedule{{Height: 100, SDKVersion: "v0.42.6"}, {Height: 200, SDKVersion: "v0.45.1"}}
+	tests := []struct {
+		name    string
+		height  uint64
+		wantErr bool
+	}{
+		{name: "before_schedule", height: 50},
+		{name: "v0.42", height: 150},
+		{name: "v0.45", height: 250, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := schedule.At(tt.height)
+			m := &Mapper{DefaultCurrency: "uatom", upgrade: &u}
+			am, err := m.amounts([]string{"100"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Mapper.amounts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (len(am) != 1 || am[0].Currency != "uatom") {
+				t.Errorf("Mapper.amounts() unexpected amounts %+v", am)
+			}
+		})
+	}
+}
@@ -307,3 +307,115 @@ func TestMapper_MsgUndelegate_Osmosis(t *testing.T) {
 		})
 	}
 }
+
+func TestMapper_MsgBeginUnlocking_Osmosis(t *testing.T) {
+	// before sdk v0.46 there is no delegator in the withdraw_rewards event, the rewards are the owner's
+	lg := types.ABCIMessageLog{MsgIndex: 0, Log: "", Events: []types.StringEvent{
+		{Type: "begin_unlock", Attributes: []types.Attribute{{Key: "period_lock_id", Value: "911"}, {Key: "owner", Value: "osmo1owner"}}},
+		{Type: "withdraw_rewards", Attributes: []types.Attribute{{Key: "amount", Value: "25uosmo"}, {Key: "validator", Value: "osmovaloper1validator"}}},
+	}}
+	m := &Mapper{
+		Logger:          zaptest.NewLogger(t),
+		DefaultCurrency: "uosmo",
+	}
+
+	gotRev, err := ParseRewardEvent("lockup", "MsgBeginUnlocking", []byte("\n\nosmo1owner\x10\x8f\x07"), lg, m)
+	if err != nil {
+		t.Fatalf("Mapper.MsgBeginUnlocking() error = %v", err)
+	}
+	wantRev := &rewstruct.RewardTx{
+		Type:      "MsgBeginUnlocking",
+		Delegator: "osmo1owner",
+		Rewards:   []*rewstruct.RewardAmount{{Amounts: []*rewstruct.Amount{{Text: "25uosmo", Currency: "uosmo", Numeric: []byte("\x19")}}, Validator: "osmovaloper1validator"}},
+	}
+	if !reflect.DeepEqual(gotRev, wantRev) {
+		t.Errorf("Mapper.MsgBeginUnlocking() = %v, want %v", gotRev, wantRev)
+	}
+}
+
+func TestMapper_MsgSuperfluidUndelegate_Osmosis(t *testing.T) {
+	type args struct {
+		msg []byte
+		lg  types.ABCIMessageLog
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantRev *rewstruct.RewardTx
+		wantErr bool
+	}{
+		{
+			// before sdk v0.46 there is no delegator in the event, superfluid withdraws rewards of the intermediary account
+			name: "MsgSuperfluidUndelegate_with_withdrawn_rewards",
+			args: args{
+				msg: []byte("\n\x0bosmo1sender\x10\x8f\x07"),
+				lg: types.ABCIMessageLog{MsgIndex: 0, Log: "", Events: []types.StringEvent{
+					{Type: "message", Attributes: []types.Attribute{{Key: "action", Value: "/osmosis.superfluid.MsgSuperfluidUndelegate"}, {Key: "module", Value: "superfluid"}, {Key: "sender", Value: "osmo1sender"}}},
+					{Type: "superfluid_undelegate", Attributes: []types.Attribute{{Key: "lock_id", Value: "911"}}},
+					{Type: "withdraw_rewards", Attributes: []types.Attribute{{Key: "amount", Value: "25uosmo"}, {Key: "validator", Value: "osmovaloper1validator"}}},
+				},
+				},
+			},
+			wantRev: &rewstruct.RewardTx{
+				Type:         "MsgSuperfluidUndelegate",
+				ValidatorSrc: "osmovaloper1validator",
+				Delegator:    "osmo1sender",
+			},
+		},
+		{
+			name: "MsgSuperfluidUndelegate_with_intermediary_rewards",
+			args: args{
+				msg: []byte("\n\x0bosmo1sender\x10\x8f\x07"),
+				lg: types.ABCIMessageLog{MsgIndex: 0, Log: "", Events: []types.StringEvent{
+					{Type: "message", Attributes: []types.Attribute{{Key: "action", Value: "/osmosis.superfluid.MsgSuperfluidUndelegate"}, {Key: "module", Value: "superfluid"}, {Key: "sender", Value: "osmo1sender"}}},
+					{Type: "superfluid_undelegate", Attributes: []types.Attribute{{Key: "lock_id", Value: "911"}}},
+					{Type: "withdraw_rewards", Attributes: []types.Attribute{{Key: "amount", Value: "25000uosmo"}, {Key: "validator", Value: "osmovaloper1validator"}, {Key: "delegator", Value: "osmo1intermediary"}}},
+				},
+				},
+			},
+			wantRev: &rewstruct.RewardTx{
+				Type:         "MsgSuperfluidUndelegate",
+				ValidatorSrc: "osmovaloper1validator",
+				Delegator:    "osmo1sender",
+			},
+		},
+		{
+			name: "MsgSuperfluidUndelegate_with_sender_rewards",
+			args: args{
+				msg: []byte("\n\x0bosmo1sender\x10\x8f\x07"),
+				lg: types.ABCIMessageLog{MsgIndex: 0, Log: "", Events: []types.StringEvent{
+					{Type: "withdraw_rewards", Attributes: []types.Attribute{{Key: "amount", Value: "25000uosmo"}, {Key: "validator", Value: "osmovaloper1validator"}, {Key: "delegator", Value: "osmo1intermediary"}}},
+					{Type: "withdraw_rewards", Attributes: []types.Attribute{{Key: "amount", Value: "25uosmo"}, {Key: "validator", Value: "osmovaloper1validator"}, {Key: "delegator", Value: "osmo1sender"}}},
+				},
+				},
+			},
+			wantRev: &rewstruct.RewardTx{
+				Type:         "MsgSuperfluidUndelegate",
+				ValidatorSrc: "osmovaloper1validator",
+				Delegator:    "osmo1sender",
+				Rewards:      []*rewstruct.RewardAmount{{Amounts: []*rewstruct.Amount{{Text: "25uosmo", Currency: "uosmo", Numeric: []byte("\x19")}}, Validator: "osmovaloper1validator"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Mapper{
+				Logger:              zaptest.NewLogger(t),
+				DefaultCurrency:     "uosmo",
+				BondedTokensPool:    "osmo1fl48vsnmsdzcv85q5d2q4z5ajdha8yu3aq6l09",
+				NotBondedTokensPool: "osmo1tygms3xhhs3yv487phx3dw4a95jn7t7lfqxwe3",
+			}
+			gotRev, err := ParseRewardEvent("superfluid", "MsgSuperfluidUndelegate", tt.args.msg, tt.args.lg, m)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Mapper.MsgSuperfluidUndelegate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotRev, tt.wantRev) {
+				t.Errorf("Mapper.MsgSuperfluidUndelegate() = %v, want %v", gotRev, tt.wantRev)
+			}
+			if v := ValidatorFromTx(gotRev); v != "osmovaloper1validator" {
+				t.Errorf("ValidatorFromTx() = %s", v)
+			}
+		})
+	}
+}
@@ -1,19 +1,19 @@
 package mapper
 
 import (
-	"fmt"
-
 	"github.com/figment-networks/indexing-engine/structs"
 
 	slashing "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	"github.com/gogo/protobuf/proto"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
 )
 
 // SlashingUnjailToSub transforms slashing.MsgUnjail sdk messages to SubsetEvent
 func (mapper *Mapper) SlashingUnjailToSub(msg []byte) (se structs.SubsetEvent, er error) {
 	unjail := &slashing.MsgUnjail{}
 	if err := proto.Unmarshal(msg, unjail); err != nil {
-		return se, fmt.Errorf("Not a unjail type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a unjail type: %w", err)
 	}
 
 	return structs.SubsetEvent{
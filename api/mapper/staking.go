@@ -1,20 +1,20 @@
 package mapper
 
 import (
-	"fmt"
-
 	"github.com/figment-networks/indexing-engine/structs"
 
 	"github.com/cosmos/cosmos-sdk/types"
 	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/gogo/protobuf/proto"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
 )
 
 // StakingUndelegateToSub transforms staking.MsgUndelegate sdk messages to SubsetEvent
 func (mapper *Mapper) StakingUndelegateToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
 	u := &staking.MsgUndelegate{}
 	if err := proto.Unmarshal(msg, u); err != nil {
-		return se, fmt.Errorf("Not a undelegate type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a undelegate type: %w", err)
 	}
 	se = structs.SubsetEvent{
 		Type:   []string{"undelegate"},
@@ -40,7 +40,7 @@ func (mapper *Mapper) StakingUndelegateToSub(msg []byte, lg types.ABCIMessageLog
 func (mapper *Mapper) StakingDelegateToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
 	d := &staking.MsgDelegate{}
 	if err := proto.Unmarshal(msg, d); err != nil {
-		return se, fmt.Errorf("Not a delegate type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a delegate type: %w", err)
 	}
 
 	se = structs.SubsetEvent{
@@ -67,7 +67,7 @@ func (mapper *Mapper) StakingDelegateToSub(msg []byte, lg types.ABCIMessageLog)
 func (mapper *Mapper) StakingBeginRedelegateToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
 	br := &staking.MsgBeginRedelegate{}
 	if err := proto.Unmarshal(msg, br); err != nil {
-		return se, fmt.Errorf("Not a begin_redelegate type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a begin_redelegate type: %w", err)
 	}
 
 	se = structs.SubsetEvent{
@@ -95,7 +95,7 @@ func (mapper *Mapper) StakingBeginRedelegateToSub(msg []byte, lg types.ABCIMessa
 func (mapper *Mapper) StakingCreateValidatorToSub(msg []byte) (se structs.SubsetEvent, err error) {
 	ev := &staking.MsgCreateValidator{}
 	if err := proto.Unmarshal(msg, ev); err != nil {
-		return se, fmt.Errorf("Not a create_validator type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a create_validator type: %w", err)
 	}
 	return structs.SubsetEvent{
 		Type:   []string{"create_validator"},
@@ -143,7 +143,7 @@ func (mapper *Mapper) StakingCreateValidatorToSub(msg []byte) (se structs.Subset
 func (mapper *Mapper) StakingEditValidatorToSub(msg []byte) (se structs.SubsetEvent, err error) {
 	ev := &staking.MsgEditValidator{}
 	if err := proto.Unmarshal(msg, ev); err != nil {
-		return se, fmt.Errorf("Not a edit_validator type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a edit_validator type: %w", err)
 	}
 	sev := structs.SubsetEvent{
 		Type:   []string{"edit_validator"},
@@ -0,0 +1,165 @@
+package mapper
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/figment-networks/indexing-engine/structs"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
+)
+
+// StakingTokenizeSharesToSub transforms staking.MsgTokenizeShares sdk messages to SubsetEvent
+func (mapper *Mapper) StakingTokenizeSharesToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	ts := &MsgTokenizeShares{}
+	if err := proto.Unmarshal(msg, ts); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a tokenize_shares type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:   []string{"tokenize_shares"},
+		Module: "staking",
+		Node: map[string][]structs.Account{
+			"delegator":   {{ID: ts.DelegatorAddress}},
+			"validator":   {{ID: ts.ValidatorAddress}},
+			"share_owner": {{ID: ts.TokenizedShareOwner}},
+		},
+		Amount: map[string]structs.TransactionAmount{
+			"tokenize": {
+				Currency: ts.Amount.Denom,
+				Numeric:  ts.Amount.Amount.BigInt(),
+				Text:     ts.Amount.String(),
+			},
+		},
+	}
+
+	// rewards of the tokenized delegation are withdrawn to the delegator,
+	// minted share tokens are sent to the owner within the same transfer event
+	err = produceFilteredTransfers(&se, "reward", skipShareTokens(ts.ValidatorAddress), lg)
+	return se, err
+}
+
+// StakingRedeemTokensForSharesToSub transforms staking.MsgRedeemTokensForShares sdk messages to SubsetEvent
+func (mapper *Mapper) StakingRedeemTokensForSharesToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	rt := &MsgRedeemTokensForShares{}
+	if err := proto.Unmarshal(msg, rt); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a redeem_tokens_for_shares type: %w", err)
+	}
+
+	validator := ShareTokenValidator(rt.Amount.Denom)
+	if val, ok := eventAttribute(lg, "redeem_shares", "validator"); ok {
+		validator = val
+	}
+
+	se = structs.SubsetEvent{
+		Type:   []string{"redeem_tokens_for_shares"},
+		Module: "staking",
+		Node: map[string][]structs.Account{
+			"delegator": {{ID: rt.DelegatorAddress}},
+		},
+		Amount: map[string]structs.TransactionAmount{
+			"redeem": {
+				Currency: rt.Amount.Denom,
+				Numeric:  rt.Amount.Amount.BigInt(),
+				Text:     rt.Amount.String(),
+			},
+		},
+	}
+	if validator != "" {
+		se.Node["validator"] = []structs.Account{{ID: validator}}
+	}
+
+	err = produceFilteredTransfers(&se, "reward", skipShareTokens(validator), lg)
+	return se, err
+}
+
+// StakingTransferTokenizeShareRecordToSub transforms staking.MsgTransferTokenizeShareRecord sdk messages to SubsetEvent
+func (mapper *Mapper) StakingTransferTokenizeShareRecordToSub(msg []byte) (se structs.SubsetEvent, err error) {
+	tr := &MsgTransferTokenizeShareRecord{}
+	if err := proto.Unmarshal(msg, tr); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a transfer_tokenize_share_record type: %w", err)
+	}
+
+	return structs.SubsetEvent{
+		Type:   []string{"transfer_tokenize_share_record"},
+		Module: "staking",
+		Node: map[string][]structs.Account{
+			"sender":    {{ID: tr.Sender}},
+			"new_owner": {{ID: tr.NewOwner}},
+		},
+		Additional: map[string][]string{
+			"tokenize_share_record_id": {strconv.FormatUint(tr.TokenizeShareRecordID, 10)},
+		},
+	}, nil
+}
+
+// StakingValidatorBondToSub transforms staking.MsgValidatorBond sdk messages to SubsetEvent
+func (mapper *Mapper) StakingValidatorBondToSub(msg []byte) (se structs.SubsetEvent, err error) {
+	vb := &MsgValidatorBond{}
+	if err := proto.Unmarshal(msg, vb); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a validator_bond type: %w", err)
+	}
+
+	return structs.SubsetEvent{
+		Type:   []string{"validator_bond"},
+		Module: "staking",
+		Node: map[string][]structs.Account{
+			"delegator": {{ID: vb.DelegatorAddress}},
+			"validator": {{ID: vb.ValidatorAddress}},
+		},
+	}, nil
+}
+
+// StakingCancelUnbondingDelegationToSub transforms staking.MsgCancelUnbondingDelegation sdk messages to SubsetEvent
+func (mapper *Mapper) StakingCancelUnbondingDelegationToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	cu := &MsgCancelUnbondingDelegation{}
+	if err := proto.Unmarshal(msg, cu); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a cancel_unbonding_delegation type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:   []string{"cancel_unbonding_delegation"},
+		Module: "staking",
+		Node: map[string][]structs.Account{
+			"delegator": {{ID: cu.DelegatorAddress}},
+			"validator": {{ID: cu.ValidatorAddress}},
+		},
+		Amount: map[string]structs.TransactionAmount{
+			"delegate": {
+				Currency: cu.Amount.Denom,
+				Numeric:  cu.Amount.Amount.BigInt(),
+				Text:     cu.Amount.String(),
+			},
+		},
+		Additional: map[string][]string{
+			"creation_height": {strconv.FormatInt(cu.CreationHeight, 10)},
+		},
+	}
+
+	// tokens are moved back from the not bonded to the bonded pool
+	err = produceTransfers(&se, "reward", mapper.BondedAddress, lg)
+	return se, err
+}
+
+// ShareTokenValidator returns the validator of the LSM share token denom ("<valoper>/<record id>"),
+// or empty string for other denoms
+func ShareTokenValidator(denom string) string {
+	i := strings.Index(denom, "/")
+	if i <= 0 || strings.Count(denom, "/") != 1 || strings.HasPrefix(denom, "ibc/") {
+		return ""
+	}
+	if _, err := strconv.ParseUint(denom[i+1:], 10, 64); err != nil {
+		return ""
+	}
+	return denom[:i]
+}
+
+// skipShareTokens skips LSM share tokens of the validator
+func skipShareTokens(validator string) func(recipient, currency string) bool {
+	return func(_, currency string) bool {
+		return validator != "" && strings.EqualFold(ShareTokenValidator(currency), validator)
+	}
+}
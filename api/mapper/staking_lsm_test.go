@@ -0,0 +1,53 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+func TestMapper_StakingTokenizeSharesToSub(t *testing.T) {
+	msg, err := proto.Marshal(&MsgTokenizeShares{
+		DelegatorAddress:    "cosmos1delegator",
+		ValidatorAddress:    "cosmosvaloper1validator",
+		Amount:              types.NewInt64Coin("uatom", 1000),
+		TokenizedShareOwner: "cosmos1delegator",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lg := types.ABCIMessageLog{Events: []types.StringEvent{
+		{Type: "transfer", Attributes: []types.Attribute{
+			{Key: "recipient", Value: "cosmos1delegator"}, {Key: "sender", Value: "cosmos1distribution"}, {Key: "amount", Value: "25uatom"},
+			{Key: "recipient", Value: "cosmos1delegator"}, {Key: "sender", Value: "cosmos1staking"}, {Key: "amount", Value: "1000cosmosvaloper1validator/7"},
+		}},
+		{Type: "tokenize_shares", Attributes: []types.Attribute{{Key: "delegator", Value: "cosmos1delegator"}, {Key: "validator", Value: "cosmosvaloper1validator"}, {Key: "amount", Value: "1000uatom"}}},
+	}}
+
+	se, err := (&Mapper{}).StakingTokenizeSharesToSub(msg, lg)
+	if err != nil {
+		t.Fatalf("StakingTokenizeSharesToSub() error = %v", err)
+	}
+
+	if am := se.Amount["tokenize"]; am.Text != "1000uatom" {
+		t.Errorf("Amount[tokenize] = %+v", am)
+	}
+	rewards := se.Transfers["reward"]
+	if len(rewards) != 1 || len(rewards[0].Amounts) != 1 || rewards[0].Amounts[0].Text != "25uatom" {
+		t.Errorf("unexpected rewards %+v", rewards)
+	}
+}
+
+func TestShareTokenValidator(t *testing.T) {
+	for denom, want := range map[string]string{
+		"cosmosvaloper1validator/7": "cosmosvaloper1validator",
+		"uatom":                     "",
+		"ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2": "",
+		"gamm/pool/1": "",
+	} {
+		if got := ShareTokenValidator(denom); got != want {
+			t.Errorf("ShareTokenValidator(%s) = %s, want %s", denom, got, want)
+		}
+	}
+}
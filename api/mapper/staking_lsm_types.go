@@ -0,0 +1,65 @@
+package mapper
+
+import (
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// cosmos.staking.v1beta1 liquid staking (LSM) messages, they are present only in the cosmos hub fork of cosmos-sdk.
+// Only the fields used by the mappers are present, the rest is skipped while decoding.
+
+// MsgTokenizeShares is cosmos.staking.v1beta1.MsgTokenizeShares
+type MsgTokenizeShares struct {
+	DelegatorAddress    string     `protobuf:"bytes,1,opt,name=delegator_address,json=delegatorAddress,proto3"`
+	ValidatorAddress    string     `protobuf:"bytes,2,opt,name=validator_address,json=validatorAddress,proto3"`
+	Amount              types.Coin `protobuf:"bytes,3,opt,name=amount,proto3"`
+	TokenizedShareOwner string     `protobuf:"bytes,4,opt,name=tokenized_share_owner,json=tokenizedShareOwner,proto3"`
+}
+
+func (m *MsgTokenizeShares) Reset()         { *m = MsgTokenizeShares{} }
+func (m *MsgTokenizeShares) String() string { return proto.CompactTextString(m) }
+func (*MsgTokenizeShares) ProtoMessage()    {}
+
+// MsgRedeemTokensForShares is cosmos.staking.v1beta1.MsgRedeemTokensForShares
+type MsgRedeemTokensForShares struct {
+	DelegatorAddress string     `protobuf:"bytes,1,opt,name=delegator_address,json=delegatorAddress,proto3"`
+	Amount           types.Coin `protobuf:"bytes,2,opt,name=amount,proto3"`
+}
+
+func (m *MsgRedeemTokensForShares) Reset()         { *m = MsgRedeemTokensForShares{} }
+func (m *MsgRedeemTokensForShares) String() string { return proto.CompactTextString(m) }
+func (*MsgRedeemTokensForShares) ProtoMessage()    {}
+
+// MsgTransferTokenizeShareRecord is cosmos.staking.v1beta1.MsgTransferTokenizeShareRecord
+type MsgTransferTokenizeShareRecord struct {
+	TokenizeShareRecordID uint64 `protobuf:"varint,1,opt,name=tokenize_share_record_id,json=tokenizeShareRecordId,proto3"`
+	Sender                string `protobuf:"bytes,2,opt,name=sender,proto3"`
+	NewOwner              string `protobuf:"bytes,3,opt,name=new_owner,json=newOwner,proto3"`
+}
+
+func (m *MsgTransferTokenizeShareRecord) Reset()         { *m = MsgTransferTokenizeShareRecord{} }
+func (m *MsgTransferTokenizeShareRecord) String() string { return proto.CompactTextString(m) }
+func (*MsgTransferTokenizeShareRecord) ProtoMessage()    {}
+
+// MsgValidatorBond is cosmos.staking.v1beta1.MsgValidatorBond
+type MsgValidatorBond struct {
+	DelegatorAddress string `protobuf:"bytes,1,opt,name=delegator_address,json=delegatorAddress,proto3"`
+	ValidatorAddress string `protobuf:"bytes,2,opt,name=validator_address,json=validatorAddress,proto3"`
+}
+
+func (m *MsgValidatorBond) Reset()         { *m = MsgValidatorBond{} }
+func (m *MsgValidatorBond) String() string { return proto.CompactTextString(m) }
+func (*MsgValidatorBond) ProtoMessage()    {}
+
+// MsgCancelUnbondingDelegation is cosmos.staking.v1beta1.MsgCancelUnbondingDelegation,
+// it's a part of cosmos-sdk v0.46+ and of the cosmos hub fork
+type MsgCancelUnbondingDelegation struct {
+	DelegatorAddress string     `protobuf:"bytes,1,opt,name=delegator_address,json=delegatorAddress,proto3"`
+	ValidatorAddress string     `protobuf:"bytes,2,opt,name=validator_address,json=validatorAddress,proto3"`
+	Amount           types.Coin `protobuf:"bytes,3,opt,name=amount,proto3"`
+	CreationHeight   int64      `protobuf:"varint,4,opt,name=creation_height,json=creationHeight,proto3"`
+}
+
+func (m *MsgCancelUnbondingDelegation) Reset()         { *m = MsgCancelUnbondingDelegation{} }
+func (m *MsgCancelUnbondingDelegation) String() string { return proto.CompactTextString(m) }
+func (*MsgCancelUnbondingDelegation) ProtoMessage()    {}
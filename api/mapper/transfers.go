@@ -1,7 +1,6 @@
 package mapper
 
 import (
-	"fmt"
 	"math/big"
 	"strings"
 
@@ -12,6 +11,13 @@ import (
 )
 
 func produceTransfers(se *structs.SubsetEvent, transferType, skipAddr string, lg types.ABCIMessageLog) (err error) {
+	return produceFilteredTransfers(se, transferType, func(recipient, _ string) bool {
+		return recipient == skipAddr
+	}, lg)
+}
+
+// produceFilteredTransfers is produceTransfers skipping every amount for which skip returns true
+func produceFilteredTransfers(se *structs.SubsetEvent, transferType string, skip func(recipient, currency string) bool, lg types.ABCIMessageLog) (err error) {
 	var evts []structs.EventTransfer
 
 	m := make(map[string][]structs.TransactionAmount)
@@ -27,10 +33,6 @@ func produceTransfers(se *structs.SubsetEvent, transferType, skipAddr string, lg
 				latestRecipient = attr.Value
 			}
 
-			if latestRecipient == skipAddr {
-				continue
-			}
-
 			if attr.Key == "amount" {
 				amounts := strings.Split(attr.Value, ",")
 				for _, amt := range amounts {
@@ -48,8 +50,11 @@ func produceTransfers(se *structs.SubsetEvent, transferType, skipAddr string, lg
 					} else {
 						c, exp, coinErr = util.GetCoin(amt)
 					}
+					if skip(latestRecipient, attrAmt.Currency) {
+						continue
+					}
 					if coinErr != nil {
-						return fmt.Errorf("[COSMOS-API] Error parsing amount '%s': %s ", amt, coinErr)
+						return util.Errorf(util.ErrAmountParse, "[COSMOS-API] Error parsing amount '%s': %s ", amt, coinErr)
 					}
 
 					attrAmt.Text = amt
@@ -1,7 +1,6 @@
 package mapper
 
 import (
-	"fmt"
 	"strconv"
 
 	"github.com/figment-networks/indexing-engine/structs"
@@ -9,6 +8,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/types"
 	vesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
 	"github.com/gogo/protobuf/proto"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
 )
 
 // VestingMsgCreateVestingAccountToSub transforms vesting.MsgCreateVestingAccount sdk messages to SubsetEvent
@@ -16,7 +17,7 @@ func (mapper *Mapper) VestingMsgCreateVestingAccountToSub(msg []byte, lg types.A
 
 	cva := &vesting.MsgCreateVestingAccount{}
 	if err := proto.Unmarshal(msg, cva); err != nil {
-		return se, fmt.Errorf("Not a msg_create_vesting_account type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a msg_create_vesting_account type: %w", err)
 	}
 
 	se = structs.SubsetEvent{
@@ -0,0 +1,328 @@
+package mapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strconv"
+
+	"github.com/figment-networks/indexing-engine/structs"
+	"github.com/figment-networks/ni-cosmoslib/util"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// WasmStoreCodeToSub transforms wasm.MsgStoreCode sdk messages to SubsetEvent
+func (mapper *Mapper) WasmStoreCodeToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	m := &wasmMsgStoreCode{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a store_code type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:       []string{"store_code"},
+		Module:     "wasm",
+		Node:       map[string][]structs.Account{"sender": {{ID: m.Sender}}},
+		Sender:     []structs.EventTransfer{{Account: structs.Account{ID: m.Sender}}},
+		Additional: map[string][]string{},
+	}
+	if codeID, ok := eventAttribute(lg, "store_code", "code_id"); ok {
+		se.Additional["code_id"] = []string{codeID}
+	}
+	if p := m.InstantiatePermission; p != nil {
+		se.Additional["instantiate_permission"] = []string{wasmAccessTypes[p.Permission]}
+		if p.Address != "" {
+			se.Additional["instantiate_permission_addresses"] = []string{p.Address}
+		}
+		if len(p.Addresses) > 0 {
+			se.Additional["instantiate_permission_addresses"] = p.Addresses
+		}
+	}
+
+	err = produceTransfers(&se, "send", "", lg)
+	return se, err
+}
+
+// WasmInstantiateContractToSub transforms wasm.MsgInstantiateContract sdk messages to SubsetEvent
+func (mapper *Mapper) WasmInstantiateContractToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	return mapper.wasmInstantiateToSub("instantiate_contract", msg, lg)
+}
+
+// WasmInstantiateContract2ToSub transforms wasm.MsgInstantiateContract2 sdk messages to SubsetEvent
+func (mapper *Mapper) WasmInstantiateContract2ToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	return mapper.wasmInstantiateToSub("instantiate_contract2", msg, lg)
+}
+
+func (mapper *Mapper) wasmInstantiateToSub(typ string, msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	m := &wasmMsgInstantiateContract{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a %s type: %w", typ, err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:   []string{typ},
+		Module: "wasm",
+		Node:   map[string][]structs.Account{"sender": {{ID: m.Sender}}},
+		Sender: []structs.EventTransfer{{Account: structs.Account{ID: m.Sender}, Amounts: CoinsToAmounts(m.Funds)}},
+		Additional: map[string][]string{
+			"code_id": {strconv.FormatUint(m.CodeID, 10)},
+			"label":   {m.Label},
+		},
+	}
+	if m.Admin != "" {
+		se.Node["admin"] = []structs.Account{{ID: m.Admin}}
+		se.Additional["admin"] = []string{m.Admin}
+	}
+	if len(m.Salt) > 0 {
+		salt, err := util.EncodeToB64(m.Salt, "salt")
+		if err != nil {
+			return se, err
+		}
+		se.Additional["salt"] = []string{salt}
+	}
+	if m.FixMsg {
+		se.Additional["fix_msg"] = []string{"true"}
+	}
+	CoinsToAmount(&se, "funds", m.Funds)
+
+	contract, ok := eventAttribute(lg, "instantiate", "_contract_address")
+	if !ok {
+		// before wasmd v0.18 the address was in the message event
+		contract, ok = eventAttribute(lg, "message", "contract_address")
+	}
+	if ok {
+		se.Node["contract"] = []structs.Account{{ID: contract}}
+		se.Additional["contract_address"] = []string{contract}
+	}
+
+	if err = wasmMsgToSub(&se, m.Msg, false); err != nil {
+		return se, err
+	}
+	if err = produceTransfers(&se, "send", "", lg); err != nil {
+		return se, err
+	}
+	err = cw20TransfersToSub(&se, lg)
+	return se, err
+}
+
+// WasmExecuteContractToSub transforms wasm.MsgExecuteContract sdk messages to SubsetEvent
+func (mapper *Mapper) WasmExecuteContractToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	m := &wasmMsgExecuteContract{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a execute_contract type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:   []string{"execute_contract"},
+		Module: "wasm",
+		Node: map[string][]structs.Account{
+			"sender":   {{ID: m.Sender}},
+			"contract": {{ID: m.Contract}},
+		},
+		Sender:     []structs.EventTransfer{{Account: structs.Account{ID: m.Sender}, Amounts: CoinsToAmounts(m.Funds)}},
+		Additional: map[string][]string{"contract_address": {m.Contract}},
+	}
+	CoinsToAmount(&se, "funds", m.Funds)
+
+	if err = wasmMsgToSub(&se, m.Msg, true); err != nil {
+		return se, err
+	}
+	if err = produceTransfers(&se, "send", "", lg); err != nil {
+		return se, err
+	}
+	err = cw20TransfersToSub(&se, lg)
+	return se, err
+}
+
+// WasmMigrateContractToSub transforms wasm.MsgMigrateContract sdk messages to SubsetEvent
+func (mapper *Mapper) WasmMigrateContractToSub(msg []byte, lg types.ABCIMessageLog) (se structs.SubsetEvent, err error) {
+	m := &wasmMsgMigrateContract{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a migrate_contract type: %w", err)
+	}
+
+	se = structs.SubsetEvent{
+		Type:   []string{"migrate_contract"},
+		Module: "wasm",
+		Node: map[string][]structs.Account{
+			"sender":   {{ID: m.Sender}},
+			"contract": {{ID: m.Contract}},
+		},
+		Sender: []structs.EventTransfer{{Account: structs.Account{ID: m.Sender}}},
+		Additional: map[string][]string{
+			"contract_address": {m.Contract},
+			"code_id":          {strconv.FormatUint(m.CodeID, 10)},
+		},
+	}
+
+	if err = wasmMsgToSub(&se, m.Msg, true); err != nil {
+		return se, err
+	}
+	if err = produceTransfers(&se, "send", "", lg); err != nil {
+		return se, err
+	}
+	err = cw20TransfersToSub(&se, lg)
+	return se, err
+}
+
+// WasmUpdateAdminToSub transforms wasm.MsgUpdateAdmin sdk messages to SubsetEvent
+func (mapper *Mapper) WasmUpdateAdminToSub(msg []byte) (se structs.SubsetEvent, err error) {
+	m := &wasmMsgUpdateAdmin{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a update_admin type: %w", err)
+	}
+
+	return structs.SubsetEvent{
+		Type:   []string{"update_admin"},
+		Module: "wasm",
+		Node: map[string][]structs.Account{
+			"sender":   {{ID: m.Sender}},
+			"contract": {{ID: m.Contract}},
+			"admin":    {{ID: m.NewAdmin}},
+		},
+		Sender: []structs.EventTransfer{{Account: structs.Account{ID: m.Sender}}},
+		Additional: map[string][]string{
+			"contract_address": {m.Contract},
+			"new_admin":        {m.NewAdmin},
+		},
+	}, nil
+}
+
+// WasmClearAdminToSub transforms wasm.MsgClearAdmin sdk messages to SubsetEvent
+func (mapper *Mapper) WasmClearAdminToSub(msg []byte) (se structs.SubsetEvent, err error) {
+	m := &wasmMsgUpdateAdmin{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a clear_admin type: %w", err)
+	}
+
+	return structs.SubsetEvent{
+		Type:   []string{"clear_admin"},
+		Module: "wasm",
+		Node: map[string][]structs.Account{
+			"sender":   {{ID: m.Sender}},
+			"contract": {{ID: m.Contract}},
+		},
+		Sender:     []structs.EventTransfer{{Account: structs.Account{ID: m.Sender}}},
+		Additional: map[string][]string{"contract_address": {m.Contract}},
+	}, nil
+}
+
+// wasmMsgToSub adds the contract JSON message to SubsetEvent, with withAction the top-level key
+// of the message (ie "transfer" for `{"transfer":{...}}`) is set as Action
+func wasmMsgToSub(se *structs.SubsetEvent, msg []byte, withAction bool) error {
+	if len(msg) == 0 {
+		return nil
+	}
+	if !json.Valid(msg) || bytes.IndexByte(msg, 0) >= 0 {
+		// Encode fields that can contain null bytes.
+		m, err := util.EncodeToB64(msg, "msg")
+		if err != nil {
+			return err
+		}
+		se.Additional["msg"] = []string{m}
+		return nil
+	}
+	se.Additional["msg"] = []string{string(msg)}
+
+	if !withAction {
+		return nil
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(msg, &obj); err != nil || len(obj) != 1 {
+		// not an enum style message
+		return nil
+	}
+	for action := range obj {
+		se.Action = action
+	}
+	return nil
+}
+
+// cw20Actions are actions of cw20 contracts that move tokens, with the attributes holding
+// the account that receives them
+var cw20Actions = map[string]string{
+	"transfer":      "to",
+	"transfer_from": "to",
+	"send":          "to",
+	"send_from":     "to",
+	"mint":          "to",
+	"burn":          "from",
+	"burn_from":     "from",
+}
+
+// cw20TransfersToSub adds token movements of cw20 contracts, parsed from wasm events, to SubsetEvent.
+// Transfers are stored as Transfers["cw20_<action>"] with the contract address as the currency, and with
+// all the attributes in Additional.
+func cw20TransfersToSub(se *structs.SubsetEvent, lg types.ABCIMessageLog) error {
+	for _, ev := range lg.GetEvents() {
+		if ev.GetType() != "wasm" {
+			continue
+		}
+
+		for _, attrs := range splitWasmEvent(ev) {
+			accountKey, ok := cw20Actions[attrs["action"]]
+			if !ok {
+				continue
+			}
+			amount, ok := attrs["amount"]
+			if !ok {
+				continue
+			}
+
+			n, ok := new(big.Int).SetString(amount, 10)
+			if !ok {
+				return util.Errorf(util.ErrAmountParse, "[COSMOS-API] Error parsing cw20 amount '%s'", amount)
+			}
+
+			contract := attrs["_contract_address"]
+			action := attrs["action"]
+			if se.Transfers == nil {
+				se.Transfers = make(map[string][]structs.EventTransfer)
+			}
+			se.Transfers["cw20_"+action] = append(se.Transfers["cw20_"+action], structs.EventTransfer{
+				Account: structs.Account{ID: attrs[accountKey]},
+				Amounts: []structs.TransactionAmount{{Currency: contract, Numeric: n, Text: amount}},
+			})
+
+			se.Additional["cw20_contract"] = append(se.Additional["cw20_contract"], contract)
+			se.Additional["cw20_action"] = append(se.Additional["cw20_action"], action)
+			se.Additional["cw20_from"] = append(se.Additional["cw20_from"], attrs["from"])
+			se.Additional["cw20_to"] = append(se.Additional["cw20_to"], attrs["to"])
+			se.Additional["cw20_amount"] = append(se.Additional["cw20_amount"], amount)
+		}
+	}
+	return nil
+}
+
+// splitWasmEvent splits the wasm event, which is merged for all the executed contracts, by the contract address
+func splitWasmEvent(ev types.StringEvent) (events []map[string]string) {
+	var current map[string]string
+	for _, attr := range ev.GetAttributes() {
+		if attr.Key == "_contract_address" || attr.Key == "contract_address" {
+			current = map[string]string{"_contract_address": attr.Value}
+			events = append(events, current)
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		current[attr.Key] = attr.Value
+	}
+	return events
+}
+
+// eventAttribute returns the value of the first attribute with the key in event of the type
+func eventAttribute(lg types.ABCIMessageLog, eventType, key string) (string, bool) {
+	for _, ev := range lg.GetEvents() {
+		if ev.GetType() != eventType {
+			continue
+		}
+		for _, attr := range ev.GetAttributes() {
+			if attr.Key == key {
+				return attr.Value, true
+			}
+		}
+	}
+	return "", false
+}
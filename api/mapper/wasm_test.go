@@ -0,0 +1,85 @@
+package mapper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+func TestMapper_WasmExecuteContractToSub(t *testing.T) {
+	msg, err := proto.Marshal(&wasmMsgExecuteContract{
+		Sender:   "juno1sender",
+		Contract: "juno1cw20",
+		Msg:      []byte(`{"transfer":{"recipient":"juno1recipient","amount":"150"}}`),
+		Funds:    types.NewCoins(types.NewInt64Coin("ujuno", 10)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lg := types.ABCIMessageLog{Events: []types.StringEvent{
+		{Type: "wasm", Attributes: []types.Attribute{
+			{Key: "_contract_address", Value: "juno1cw20"},
+			{Key: "action", Value: "transfer"},
+			{Key: "from", Value: "juno1sender"},
+			{Key: "to", Value: "juno1recipient"},
+			{Key: "amount", Value: "150"},
+			{Key: "_contract_address", Value: "juno1other"},
+			{Key: "action", Value: "increment"},
+		}},
+	}}
+
+	se, err := (&Mapper{}).WasmExecuteContractToSub(msg, lg)
+	if err != nil {
+		t.Fatalf("Mapper.WasmExecuteContractToSub() error = %v", err)
+	}
+
+	if se.Action != "transfer" {
+		t.Errorf("unexpected action %q", se.Action)
+	}
+	if am := se.Amount["funds"]; am.Currency != "ujuno" || am.Text != "10" {
+		t.Errorf("unexpected funds %+v", am)
+	}
+	cw20 := se.Transfers["cw20_transfer"]
+	if len(cw20) != 1 || cw20[0].Account.ID != "juno1recipient" || cw20[0].Amounts[0].Currency != "juno1cw20" || cw20[0].Amounts[0].Numeric.Int64() != 150 {
+		t.Errorf("unexpected cw20 transfers %+v", cw20)
+	}
+	for k, want := range map[string][]string{
+		"contract_address": {"juno1cw20"},
+		"cw20_from":        {"juno1sender"},
+		"cw20_to":          {"juno1recipient"},
+		"cw20_amount":      {"150"},
+	} {
+		if got := se.Additional[k]; !reflect.DeepEqual(got, want) {
+			t.Errorf("Additional[%s] = %v, want %v", k, got, want)
+		}
+	}
+}
+
+func TestMapper_WasmInstantiateContractToSub(t *testing.T) {
+	msg, err := proto.Marshal(&wasmMsgInstantiateContract{
+		Sender: "juno1sender",
+		Admin:  "juno1admin",
+		CodeID: 42,
+		Label:  "label",
+		Msg:    []byte(`{"name":"token","symbol":"TKN"}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lg := types.ABCIMessageLog{Events: []types.StringEvent{
+		{Type: "instantiate", Attributes: []types.Attribute{{Key: "_contract_address", Value: "juno1contract"}, {Key: "code_id", Value: "42"}}},
+	}}
+
+	se, err := (&Mapper{}).WasmInstantiateContractToSub(msg, lg)
+	if err != nil {
+		t.Fatalf("Mapper.WasmInstantiateContractToSub() error = %v", err)
+	}
+	if se.Action != "" {
+		t.Errorf("instantiate message shouldn't have action, got %q", se.Action)
+	}
+	if se.Node["contract"][0].ID != "juno1contract" || se.Node["admin"][0].ID != "juno1admin" || se.Additional["code_id"][0] != "42" {
+		t.Errorf("unexpected event %+v", se)
+	}
+}
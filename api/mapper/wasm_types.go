@@ -0,0 +1,86 @@
+package mapper
+
+import (
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// cosmwasm.wasm.v1 messages, wasmd types can't be used as they require cgo (wasmvm).
+// Only the fields used by the mappers are present, the rest is skipped while decoding.
+
+type wasmAccessConfig struct {
+	Permission int32    `protobuf:"varint,1,opt,name=permission,proto3,enum=cosmwasm.wasm.v1.AccessType"`
+	Address    string   `protobuf:"bytes,2,opt,name=address,proto3"`
+	Addresses  []string `protobuf:"bytes,3,rep,name=addresses,proto3"`
+}
+
+func (m *wasmAccessConfig) Reset()         { *m = wasmAccessConfig{} }
+func (m *wasmAccessConfig) String() string { return proto.CompactTextString(m) }
+func (*wasmAccessConfig) ProtoMessage()    {}
+
+// wasmAccessTypes are names of cosmwasm.wasm.v1.AccessType values
+var wasmAccessTypes = map[int32]string{
+	0: "ACCESS_TYPE_UNSPECIFIED",
+	1: "ACCESS_TYPE_NOBODY",
+	2: "ACCESS_TYPE_ONLY_ADDRESS",
+	3: "ACCESS_TYPE_EVERYBODY",
+	4: "ACCESS_TYPE_ANY_OF_ADDRESSES",
+}
+
+type wasmMsgStoreCode struct {
+	Sender                string            `protobuf:"bytes,1,opt,name=sender,proto3"`
+	InstantiatePermission *wasmAccessConfig `protobuf:"bytes,5,opt,name=instantiate_permission,json=instantiatePermission,proto3"`
+}
+
+func (m *wasmMsgStoreCode) Reset()         { *m = wasmMsgStoreCode{} }
+func (m *wasmMsgStoreCode) String() string { return proto.CompactTextString(m) }
+func (*wasmMsgStoreCode) ProtoMessage()    {}
+
+// wasmMsgInstantiateContract is both MsgInstantiateContract and MsgInstantiateContract2
+type wasmMsgInstantiateContract struct {
+	Sender string      `protobuf:"bytes,1,opt,name=sender,proto3"`
+	Admin  string      `protobuf:"bytes,2,opt,name=admin,proto3"`
+	CodeID uint64      `protobuf:"varint,3,opt,name=code_id,json=codeId,proto3"`
+	Label  string      `protobuf:"bytes,4,opt,name=label,proto3"`
+	Msg    []byte      `protobuf:"bytes,5,opt,name=msg,proto3"`
+	Funds  types.Coins `protobuf:"bytes,6,rep,name=funds,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins"`
+	Salt   []byte      `protobuf:"bytes,7,opt,name=salt,proto3"`
+	FixMsg bool        `protobuf:"varint,8,opt,name=fix_msg,json=fixMsg,proto3"`
+}
+
+func (m *wasmMsgInstantiateContract) Reset()         { *m = wasmMsgInstantiateContract{} }
+func (m *wasmMsgInstantiateContract) String() string { return proto.CompactTextString(m) }
+func (*wasmMsgInstantiateContract) ProtoMessage()    {}
+
+type wasmMsgExecuteContract struct {
+	Sender   string      `protobuf:"bytes,1,opt,name=sender,proto3"`
+	Contract string      `protobuf:"bytes,2,opt,name=contract,proto3"`
+	Msg      []byte      `protobuf:"bytes,3,opt,name=msg,proto3"`
+	Funds    types.Coins `protobuf:"bytes,5,rep,name=funds,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins"`
+}
+
+func (m *wasmMsgExecuteContract) Reset()         { *m = wasmMsgExecuteContract{} }
+func (m *wasmMsgExecuteContract) String() string { return proto.CompactTextString(m) }
+func (*wasmMsgExecuteContract) ProtoMessage()    {}
+
+type wasmMsgMigrateContract struct {
+	Sender   string `protobuf:"bytes,1,opt,name=sender,proto3"`
+	Contract string `protobuf:"bytes,2,opt,name=contract,proto3"`
+	CodeID   uint64 `protobuf:"varint,3,opt,name=code_id,json=codeId,proto3"`
+	Msg      []byte `protobuf:"bytes,4,opt,name=msg,proto3"`
+}
+
+func (m *wasmMsgMigrateContract) Reset()         { *m = wasmMsgMigrateContract{} }
+func (m *wasmMsgMigrateContract) String() string { return proto.CompactTextString(m) }
+func (*wasmMsgMigrateContract) ProtoMessage()    {}
+
+// wasmMsgUpdateAdmin is both MsgUpdateAdmin and MsgClearAdmin (without NewAdmin)
+type wasmMsgUpdateAdmin struct {
+	Sender   string `protobuf:"bytes,1,opt,name=sender,proto3"`
+	NewAdmin string `protobuf:"bytes,2,opt,name=new_admin,json=newAdmin,proto3"`
+	Contract string `protobuf:"bytes,3,opt,name=contract,proto3"`
+}
+
+func (m *wasmMsgUpdateAdmin) Reset()         { *m = wasmMsgUpdateAdmin{} }
+func (m *wasmMsgUpdateAdmin) String() string { return proto.CompactTextString(m) }
+func (*wasmMsgUpdateAdmin) ProtoMessage()    {}
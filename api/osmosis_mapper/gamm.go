@@ -0,0 +1,453 @@
+package osmosis_mapper
+
+import (
+	"strconv"
+
+	shared "github.com/figment-networks/indexing-engine/structs"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
+)
+
+// see types https://github.com/osmosis-labs/osmosis/blob/main/proto/osmosis/gamm/v1beta1/tx.proto
+// and https://github.com/osmosis-labs/osmosis/blob/main/proto/osmosis/poolmanager/v1beta1/tx.proto
+
+const (
+	moduleGamm        = "osmosis_gamm"
+	modulePoolManager = "osmosis_poolmanager"
+)
+
+// OsmosisSwapExactAmountIn transforms gamm.MsgSwapExactAmountIn sdk messages to SubsetEvent
+func OsmosisSwapExactAmountIn(msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	return swapExactAmountIn(moduleGamm, msg, lg)
+}
+
+// OsmosisPoolManagerSwapExactAmountIn transforms poolmanager.MsgSwapExactAmountIn sdk messages to SubsetEvent
+func OsmosisPoolManagerSwapExactAmountIn(msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	return swapExactAmountIn(modulePoolManager, msg, lg)
+}
+
+func swapExactAmountIn(module string, msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	m := &msgSwapExactAmountIn{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a swap_exact_amount_in type: %w", err)
+	}
+
+	tokenIn := coinAmount(m.TokenIn)
+	se = shared.SubsetEvent{
+		Type:   []string{"swap_exact_amount_in"},
+		Module: module,
+		Node:   map[string][]shared.Account{"sender": {{ID: m.Sender}}},
+		Sender: []shared.EventTransfer{{
+			Account: shared.Account{ID: m.Sender},
+			Amounts: []shared.TransactionAmount{tokenIn},
+		}},
+		Amount:     map[string]shared.TransactionAmount{"token_in": tokenIn},
+		Additional: map[string][]string{},
+	}
+	routesToSub(&se, m.Routes)
+
+	if len(m.Routes) > 0 {
+		min, err := intAmount(m.TokenOutMinAmount, m.Routes[len(m.Routes)-1].Denom)
+		if err != nil {
+			return se, err
+		}
+		se.Amount["token_out_min"] = min
+	}
+
+	swaps, err := swapsToSub(&se, lg)
+	if err != nil {
+		return se, err
+	}
+	if len(swaps) == 0 {
+		return se, missingEvent(lg, "token_swapped")
+	}
+	// the last hop is the one which swaps to the requested denom
+	out := swaps[len(swaps)-1].tokensOut
+	se.Recipient = []shared.EventTransfer{{Account: shared.Account{ID: m.Sender}, Amounts: out}}
+	amountsToSub(&se, "token_out", out)
+
+	return se, nil
+}
+
+// OsmosisSwapExactAmountOut transforms gamm.MsgSwapExactAmountOut sdk messages to SubsetEvent
+func OsmosisSwapExactAmountOut(msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	return swapExactAmountOut(moduleGamm, msg, lg)
+}
+
+// OsmosisPoolManagerSwapExactAmountOut transforms poolmanager.MsgSwapExactAmountOut sdk messages to SubsetEvent
+func OsmosisPoolManagerSwapExactAmountOut(msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	return swapExactAmountOut(modulePoolManager, msg, lg)
+}
+
+func swapExactAmountOut(module string, msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	m := &msgSwapExactAmountOut{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a swap_exact_amount_out type: %w", err)
+	}
+
+	tokenOut := coinAmount(m.TokenOut)
+	se = shared.SubsetEvent{
+		Type:   []string{"swap_exact_amount_out"},
+		Module: module,
+		Node:   map[string][]shared.Account{"sender": {{ID: m.Sender}}},
+		Recipient: []shared.EventTransfer{{
+			Account: shared.Account{ID: m.Sender},
+			Amounts: []shared.TransactionAmount{tokenOut},
+		}},
+		Amount:     map[string]shared.TransactionAmount{"token_out": tokenOut},
+		Additional: map[string][]string{},
+	}
+	routesToSub(&se, m.Routes)
+
+	if len(m.Routes) > 0 {
+		max, err := intAmount(m.TokenInMaxAmount, m.Routes[0].Denom)
+		if err != nil {
+			return se, err
+		}
+		se.Amount["token_in_max"] = max
+	}
+
+	swaps, err := swapsToSub(&se, lg)
+	if err != nil {
+		return se, err
+	}
+	if len(swaps) == 0 {
+		return se, missingEvent(lg, "token_swapped")
+	}
+	// the first route is the one which swaps the sent denom, depending on the version hops can be executed in reverse
+	in := swaps[0].tokensIn
+	for _, s := range swaps {
+		if len(m.Routes) > 0 && s.poolID == strconv.FormatUint(m.Routes[0].PoolId, 10) {
+			in = s.tokensIn
+			break
+		}
+	}
+	se.Sender = []shared.EventTransfer{{Account: shared.Account{ID: m.Sender}, Amounts: in}}
+	amountsToSub(&se, "token_in", in)
+
+	return se, nil
+}
+
+// OsmosisJoinPool transforms gamm.MsgJoinPool sdk messages to SubsetEvent
+func OsmosisJoinPool(msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	m := &msgJoinExitPool{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a join_pool type: %w", err)
+	}
+
+	se = poolEvent("join_pool", m.Sender, m.PoolId)
+	shares, err := intAmount(m.ShareAmount, poolShareDenom(m.PoolId))
+	if err != nil {
+		return se, err
+	}
+	se.Amount["share_out"] = shares
+	se.Recipient = []shared.EventTransfer{{Account: shared.Account{ID: m.Sender}, Amounts: []shared.TransactionAmount{shares}}}
+	amountsToSub(&se, "token_in_max", coinsAmounts(m.TokenLimits))
+
+	in, err := poolTokens(lg, "pool_joined", "tokens_in")
+	if err != nil {
+		return se, err
+	}
+	se.Sender = []shared.EventTransfer{{Account: shared.Account{ID: m.Sender}, Amounts: in}}
+	amountsToSub(&se, "token_in", in)
+
+	return se, nil
+}
+
+// OsmosisExitPool transforms gamm.MsgExitPool sdk messages to SubsetEvent
+func OsmosisExitPool(msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	m := &msgJoinExitPool{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a exit_pool type: %w", err)
+	}
+
+	se = poolEvent("exit_pool", m.Sender, m.PoolId)
+	shares, err := intAmount(m.ShareAmount, poolShareDenom(m.PoolId))
+	if err != nil {
+		return se, err
+	}
+	se.Amount["share_in"] = shares
+	se.Sender = []shared.EventTransfer{{Account: shared.Account{ID: m.Sender}, Amounts: []shared.TransactionAmount{shares}}}
+	amountsToSub(&se, "token_out_min", coinsAmounts(m.TokenLimits))
+
+	out, err := poolTokens(lg, "pool_exited", "tokens_out")
+	if err != nil {
+		return se, err
+	}
+	se.Recipient = []shared.EventTransfer{{Account: shared.Account{ID: m.Sender}, Amounts: out}}
+	amountsToSub(&se, "token_out", out)
+
+	return se, nil
+}
+
+// OsmosisJoinSwapExternAmountIn transforms gamm.MsgJoinSwapExternAmountIn sdk messages to SubsetEvent
+func OsmosisJoinSwapExternAmountIn(msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	m := &msgJoinExitSwapExtern{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a join_swap_extern_amount_in type: %w", err)
+	}
+
+	se = poolEvent("join_swap_extern_amount_in", m.Sender, m.PoolId)
+	in := coinAmount(m.Token)
+	se.Amount["token_in"] = in
+	se.Sender = []shared.EventTransfer{{Account: shared.Account{ID: m.Sender}, Amounts: []shared.TransactionAmount{in}}}
+
+	min, err := intAmount(m.ShareAmount, poolShareDenom(m.PoolId))
+	if err != nil {
+		return se, err
+	}
+	se.Amount["share_out_min"] = min
+
+	err = sharesToSub(&se, lg, "share_out", m.PoolId, "recipient", m.Sender)
+	return se, err
+}
+
+// OsmosisExitSwapExternAmountOut transforms gamm.MsgExitSwapExternAmountOut sdk messages to SubsetEvent
+func OsmosisExitSwapExternAmountOut(msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	m := &msgJoinExitSwapExtern{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a exit_swap_extern_amount_out type: %w", err)
+	}
+
+	se = poolEvent("exit_swap_extern_amount_out", m.Sender, m.PoolId)
+	out := coinAmount(m.Token)
+	se.Amount["token_out"] = out
+	se.Recipient = []shared.EventTransfer{{Account: shared.Account{ID: m.Sender}, Amounts: []shared.TransactionAmount{out}}}
+
+	max, err := intAmount(m.ShareAmount, poolShareDenom(m.PoolId))
+	if err != nil {
+		return se, err
+	}
+	se.Amount["share_in_max"] = max
+
+	err = sharesToSub(&se, lg, "share_in", m.PoolId, "sender", m.Sender)
+	return se, err
+}
+
+// OsmosisJoinSwapShareAmountOut transforms gamm.MsgJoinSwapShareAmountOut sdk messages to SubsetEvent
+func OsmosisJoinSwapShareAmountOut(msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	m := &msgJoinExitSwapShare{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a join_swap_share_amount_out type: %w", err)
+	}
+
+	se = poolEvent("join_swap_share_amount_out", m.Sender, m.PoolId)
+	shares, err := intAmount(m.ShareAmount, poolShareDenom(m.PoolId))
+	if err != nil {
+		return se, err
+	}
+	se.Amount["share_out"] = shares
+	se.Recipient = []shared.EventTransfer{{Account: shared.Account{ID: m.Sender}, Amounts: []shared.TransactionAmount{shares}}}
+
+	max, err := intAmount(m.TokenLimit, m.TokenDenom)
+	if err != nil {
+		return se, err
+	}
+	se.Amount["token_in_max"] = max
+
+	in, err := poolTokens(lg, "pool_joined", "tokens_in")
+	if err != nil {
+		return se, err
+	}
+	se.Sender = []shared.EventTransfer{{Account: shared.Account{ID: m.Sender}, Amounts: in}}
+	amountsToSub(&se, "token_in", in)
+
+	return se, nil
+}
+
+// OsmosisExitSwapShareAmountIn transforms gamm.MsgExitSwapShareAmountIn sdk messages to SubsetEvent
+func OsmosisExitSwapShareAmountIn(msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	m := &msgJoinExitSwapShare{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a exit_swap_share_amount_in type: %w", err)
+	}
+
+	se = poolEvent("exit_swap_share_amount_in", m.Sender, m.PoolId)
+	shares, err := intAmount(m.ShareAmount, poolShareDenom(m.PoolId))
+	if err != nil {
+		return se, err
+	}
+	se.Amount["share_in"] = shares
+	se.Sender = []shared.EventTransfer{{Account: shared.Account{ID: m.Sender}, Amounts: []shared.TransactionAmount{shares}}}
+
+	min, err := intAmount(m.TokenLimit, m.TokenDenom)
+	if err != nil {
+		return se, err
+	}
+	se.Amount["token_out_min"] = min
+
+	out, err := poolTokens(lg, "pool_exited", "tokens_out")
+	if err != nil {
+		return se, err
+	}
+	se.Recipient = []shared.EventTransfer{{Account: shared.Account{ID: m.Sender}, Amounts: out}}
+	amountsToSub(&se, "token_out", out)
+
+	return se, nil
+}
+
+func poolEvent(typ, sender string, poolID uint64) shared.SubsetEvent {
+	return shared.SubsetEvent{
+		Type:       []string{typ},
+		Module:     moduleGamm,
+		Node:       map[string][]shared.Account{"sender": {{ID: sender}}},
+		Amount:     map[string]shared.TransactionAmount{},
+		Additional: map[string][]string{"pool_id": {strconv.FormatUint(poolID, 10)}},
+	}
+}
+
+// poolShareDenom returns denom of the pool LP shares
+func poolShareDenom(poolID uint64) string {
+	return "gamm/pool/" + strconv.FormatUint(poolID, 10)
+}
+
+func routesToSub(se *shared.SubsetEvent, routes []*swapRoute) {
+	for _, r := range routes {
+		se.Additional["route_pool_ids"] = append(se.Additional["route_pool_ids"], strconv.FormatUint(r.PoolId, 10))
+		se.Additional["route_denoms"] = append(se.Additional["route_denoms"], r.Denom)
+	}
+}
+
+type swap struct {
+	poolID    string
+	tokensIn  []shared.TransactionAmount
+	tokensOut []shared.TransactionAmount
+}
+
+// swapsToSub adds every hop from the token_swapped events to SubsetEvent
+func swapsToSub(se *shared.SubsetEvent, lg sdk.ABCIMessageLog) (swaps []swap, err error) {
+	for _, attrs := range eventsByType(lg, "token_swapped") {
+		s := swap{poolID: attrs["pool_id"]}
+		if s.tokensIn, err = parseAmounts(attrs["tokens_in"]); err != nil {
+			return nil, err
+		}
+		if s.tokensOut, err = parseAmounts(attrs["tokens_out"]); err != nil {
+			return nil, err
+		}
+		swaps = append(swaps, s)
+
+		se.Additional["swapped_pool_ids"] = append(se.Additional["swapped_pool_ids"], attrs["pool_id"])
+		se.Additional["swapped_tokens_in"] = append(se.Additional["swapped_tokens_in"], attrs["tokens_in"])
+		se.Additional["swapped_tokens_out"] = append(se.Additional["swapped_tokens_out"], attrs["tokens_out"])
+	}
+	return swaps, nil
+}
+
+// poolTokens returns tokens from pool_joined or pool_exited event
+func poolTokens(lg sdk.ABCIMessageLog, eventType, key string) (amounts []shared.TransactionAmount, err error) {
+	events := eventsByType(lg, eventType)
+	if len(events) == 0 {
+		return nil, missingEvent(lg, eventType)
+	}
+	for _, attrs := range events {
+		a, err := parseAmounts(attrs[key])
+		if err != nil {
+			return nil, err
+		}
+		amounts = append(amounts, a...)
+	}
+	return amounts, nil
+}
+
+// missingEvent returns ErrMissingEvent for the log without the event, logs of failed transactions are empty
+func missingEvent(lg sdk.ABCIMessageLog, eventType string) error {
+	if len(lg.GetEvents()) == 0 {
+		return nil
+	}
+	return util.Errorf(util.ErrMissingEvent, "missing events type: %s", eventType)
+}
+
+// sharesToSub adds LP shares transferred from or to the account, as they are not present in the pool events
+func sharesToSub(se *shared.SubsetEvent, lg sdk.ABCIMessageLog, key string, poolID uint64, accountKey, account string) error {
+	denom := poolShareDenom(poolID)
+	for _, attrs := range eventsByType(lg, "transfer") {
+		if attrs[accountKey] != account {
+			continue
+		}
+		amounts, err := parseAmounts(attrs["amount"])
+		if err != nil {
+			return err
+		}
+		for _, a := range amounts {
+			if a.Currency != denom {
+				continue
+			}
+			se.Amount[key] = a
+			transfer := shared.EventTransfer{Account: shared.Account{ID: account}, Amounts: []shared.TransactionAmount{a}}
+			if accountKey == "recipient" {
+				se.Recipient = append(se.Recipient, transfer)
+			} else {
+				se.Sender = append(se.Sender, transfer)
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// eventsByType returns attributes of every event of the type, all the events of the same type
+// are merged in the log so they are split on the first attribute key
+func eventsByType(lg sdk.ABCIMessageLog, eventType string) (events []map[string]string) {
+	for _, ev := range lg.GetEvents() {
+		if ev.GetType() != eventType || len(ev.GetAttributes()) == 0 {
+			continue
+		}
+		first := ev.GetAttributes()[0].Key
+		var current map[string]string
+		for _, attr := range ev.GetAttributes() {
+			if attr.Key == first {
+				current = map[string]string{}
+				events = append(events, current)
+			}
+			current[attr.Key] = attr.Value
+		}
+	}
+	return events
+}
+
+func parseAmounts(coins string) ([]shared.TransactionAmount, error) {
+	if coins == "" {
+		return nil, nil
+	}
+	c, err := sdk.ParseCoinsNormalized(coins)
+	if err != nil {
+		return nil, util.Errorf(util.ErrAmountParse, "[COSMOS-API] Error parsing amount '%s': %w", coins, err)
+	}
+	return coinsAmounts(c), nil
+}
+
+func intAmount(amount, denom string) (shared.TransactionAmount, error) {
+	i, ok := sdk.NewIntFromString(amount)
+	if !ok {
+		return shared.TransactionAmount{}, util.Errorf(util.ErrAmountParse, "invalid amount %q", amount)
+	}
+	return coinAmount(sdk.Coin{Denom: denom, Amount: i}), nil
+}
+
+func coinAmount(coin sdk.Coin) shared.TransactionAmount {
+	return shared.TransactionAmount{
+		Currency: coin.Denom,
+		Numeric:  coin.Amount.BigInt(),
+		Text:     coin.Amount.String(),
+	}
+}
+
+func coinsAmounts(coins sdk.Coins) (amounts []shared.TransactionAmount) {
+	for _, coin := range coins {
+		amounts = append(amounts, coinAmount(coin))
+	}
+	return amounts
+}
+
+func amountsToSub(se *shared.SubsetEvent, key string, amounts []shared.TransactionAmount) {
+	for i, am := range amounts {
+		k := key
+		if i > 0 {
+			k += "_" + strconv.Itoa(i)
+		}
+		se.Amount[k] = am
+	}
+}
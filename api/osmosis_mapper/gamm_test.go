@@ -0,0 +1,135 @@
+package osmosis_mapper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
+)
+
+func TestOsmosisSwapExactAmountIn(t *testing.T) {
+	msg, err := proto.Marshal(&msgSwapExactAmountIn{
+		Sender:            "osmo1sender",
+		Routes:            []*swapRoute{{PoolId: 1, Denom: "uatom"}, {PoolId: 7, Denom: "uion"}},
+		TokenIn:           sdk.NewInt64Coin("uosmo", 1000),
+		TokenOutMinAmount: "5",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lg := sdk.ABCIMessageLog{Events: []sdk.StringEvent{
+		{Type: "token_swapped", Attributes: []sdk.Attribute{
+			{Key: "module", Value: "gamm"}, {Key: "sender", Value: "osmo1sender"}, {Key: "pool_id", Value: "1"}, {Key: "tokens_in", Value: "1000uosmo"}, {Key: "tokens_out", Value: "80uatom"},
+			{Key: "module", Value: "gamm"}, {Key: "sender", Value: "osmo1sender"}, {Key: "pool_id", Value: "7"}, {Key: "tokens_in", Value: "80uatom"}, {Key: "tokens_out", Value: "6uion"},
+		}},
+	}}
+
+	se, err := OsmosisSwapExactAmountIn(msg, lg)
+	if err != nil {
+		t.Fatalf("OsmosisSwapExactAmountIn() error = %v", err)
+	}
+
+	for k, want := range map[string]string{"token_in": "1000uosmo", "token_out_min": "5uion", "token_out": "6uion"} {
+		if am := se.Amount[k]; am.Text+am.Currency != want {
+			t.Errorf("Amount[%s] = %+v, want %s", k, am, want)
+		}
+	}
+	if !reflect.DeepEqual(se.Additional["route_pool_ids"], []string{"1", "7"}) || !reflect.DeepEqual(se.Additional["swapped_pool_ids"], []string{"1", "7"}) {
+		t.Errorf("unexpected routes %v", se.Additional)
+	}
+	if len(se.Recipient) != 1 || se.Recipient[0].Amounts[0].Currency != "uion" {
+		t.Errorf("unexpected recipient %+v", se.Recipient)
+	}
+}
+
+func TestOsmosisJoinPool(t *testing.T) {
+	msg, err := proto.Marshal(&msgJoinExitPool{
+		Sender:      "osmo1sender",
+		PoolId:      1,
+		ShareAmount: "100000",
+		TokenLimits: sdk.NewCoins(sdk.NewInt64Coin("uatom", 20), sdk.NewInt64Coin("uosmo", 200)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lg := sdk.ABCIMessageLog{Events: []sdk.StringEvent{
+		{Type: "pool_joined", Attributes: []sdk.Attribute{
+			{Key: "module", Value: "gamm"}, {Key: "sender", Value: "osmo1sender"}, {Key: "pool_id", Value: "1"}, {Key: "tokens_in", Value: "19uatom,190uosmo"},
+		}},
+	}}
+
+	se, err := OsmosisJoinPool(msg, lg)
+	if err != nil {
+		t.Fatalf("OsmosisJoinPool() error = %v", err)
+	}
+
+	for k, want := range map[string]string{"share_out": "100000gamm/pool/1", "token_in_max": "20uatom", "token_in": "19uatom", "token_in_1": "190uosmo"} {
+		if am := se.Amount[k]; am.Text+am.Currency != want {
+			t.Errorf("Amount[%s] = %+v, want %s", k, am, want)
+		}
+	}
+	if len(se.Sender) != 1 || len(se.Sender[0].Amounts) != 2 {
+		t.Errorf("unexpected sender %+v", se.Sender)
+	}
+}
+
+func TestOsmosisGamm_Errors(t *testing.T) {
+	swap, err := proto.Marshal(&msgSwapExactAmountIn{Sender: "osmo1sender", Routes: []*swapRoute{{PoolId: 1, Denom: "uatom"}}, TokenIn: sdk.NewInt64Coin("uosmo", 1000), TokenOutMinAmount: "5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	join, err := proto.Marshal(&msgJoinExitPool{Sender: "osmo1sender", PoolId: 1, ShareAmount: "100000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	badShares, err := proto.Marshal(&msgJoinExitPool{Sender: "osmo1sender", PoolId: 1, ShareAmount: "abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	transfer := sdk.ABCIMessageLog{Events: []sdk.StringEvent{
+		{Type: "transfer", Attributes: []sdk.Attribute{{Key: "recipient", Value: "osmo1pool"}, {Key: "sender", Value: "osmo1sender"}, {Key: "amount", Value: "1000uosmo"}}},
+	}}
+
+	tests := []struct {
+		name    string
+		fn      func([]byte, sdk.ABCIMessageLog) (err error)
+		msg     []byte
+		lg      sdk.ABCIMessageLog
+		wantErr error
+	}{
+		{name: "swap_missing_token_swapped", fn: swapErr, msg: swap, lg: transfer, wantErr: util.ErrMissingEvent},
+		{name: "swap_failed_transaction", fn: swapErr, msg: swap},
+		{name: "join_missing_pool_joined", fn: joinErr, msg: join, lg: transfer, wantErr: util.ErrMissingEvent},
+		{name: "join_failed_transaction", fn: joinErr, msg: join},
+		{name: "join_share_amount", fn: joinErr, msg: badShares, wantErr: util.ErrAmountParse},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fn(tt.msg, tt.lg)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			var me *util.MappingError
+			if !errors.As(err, &me) || me.Kind != tt.wantErr {
+				t.Errorf("error = %v, want kind %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func swapErr(msg []byte, lg sdk.ABCIMessageLog) error {
+	_, err := OsmosisSwapExactAmountIn(msg, lg)
+	return err
+}
+
+func joinErr(msg []byte, lg sdk.ABCIMessageLog) error {
+	_, err := OsmosisJoinPool(msg, lg)
+	return err
+}
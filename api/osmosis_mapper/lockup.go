@@ -0,0 +1,215 @@
+package osmosis_mapper
+
+import (
+	"strconv"
+
+	shared "github.com/figment-networks/indexing-engine/structs"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
+)
+
+// see types https://github.com/osmosis-labs/osmosis/blob/main/proto/osmosis/lockup/tx.proto
+// and https://github.com/osmosis-labs/osmosis/blob/main/proto/osmosis/superfluid/tx.proto
+
+const (
+	moduleLockup     = "osmosis_lockup"
+	moduleSuperfluid = "osmosis_superfluid"
+)
+
+// OsmosisLockTokens transforms lockup.MsgLockTokens sdk messages to SubsetEvent
+func OsmosisLockTokens(msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	m := &msgLockTokens{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a lock_tokens type: %w", err)
+	}
+
+	se = shared.SubsetEvent{
+		Type:   []string{"lock_tokens"},
+		Module: moduleLockup,
+		Node:   map[string][]shared.Account{"owner": {{ID: m.Owner}}},
+		Sender: []shared.EventTransfer{{
+			Account: shared.Account{ID: m.Owner},
+			Amounts: coinsAmounts(m.Coins),
+		}},
+		Amount:     map[string]shared.TransactionAmount{},
+		Additional: map[string][]string{"duration": {m.Duration.String()}},
+	}
+	amountsToSub(&se, "lock", coinsAmounts(m.Coins))
+
+	for _, attrs := range eventsByType(lg, "lock_tokens") {
+		se.Additional["lock_id"] = append(se.Additional["lock_id"], attrs["period_lock_id"])
+	}
+
+	return se, nil
+}
+
+// OsmosisBeginUnlocking transforms lockup.MsgBeginUnlocking sdk messages to SubsetEvent
+func OsmosisBeginUnlocking(msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	m := &msgBeginUnlocking{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a begin_unlocking type: %w", err)
+	}
+
+	se = shared.SubsetEvent{
+		Type:       []string{"begin_unlocking"},
+		Module:     moduleLockup,
+		Node:       map[string][]shared.Account{"owner": {{ID: m.Owner}}},
+		Amount:     map[string]shared.TransactionAmount{},
+		Additional: map[string][]string{"lock_id": {strconv.FormatUint(m.ID, 10)}},
+	}
+	// no coins means the whole lock is unlocked
+	amountsToSub(&se, "unlock", coinsAmounts(m.Coins))
+
+	for _, attrs := range eventsByType(lg, "begin_unlock") {
+		se.Additional["unlock_time"] = append(se.Additional["unlock_time"], attrs["unlock_time"])
+	}
+
+	err = rewardsToSub(&se, lg, m.Owner, false)
+	return se, err
+}
+
+// OsmosisSuperfluidDelegate transforms superfluid.MsgSuperfluidDelegate sdk messages to SubsetEvent
+func OsmosisSuperfluidDelegate(msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	return superfluidToSub("superfluid_delegate", msg, lg)
+}
+
+// OsmosisSuperfluidUndelegate transforms superfluid.MsgSuperfluidUndelegate sdk messages to SubsetEvent
+func OsmosisSuperfluidUndelegate(msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	return superfluidToSub("superfluid_undelegate", msg, lg)
+}
+
+// OsmosisSuperfluidUnbondLock transforms superfluid.MsgSuperfluidUnbondLock sdk messages to SubsetEvent
+func OsmosisSuperfluidUnbondLock(msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	return superfluidToSub("superfluid_unbond_lock", msg, lg)
+}
+
+func superfluidToSub(typ string, msg []byte, lg sdk.ABCIMessageLog) (se shared.SubsetEvent, err error) {
+	m := &msgSuperfluid{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a %s type: %w", typ, err)
+	}
+
+	se = shared.SubsetEvent{
+		Type:       []string{typ},
+		Module:     moduleSuperfluid,
+		Node:       map[string][]shared.Account{"delegator": {{ID: m.Sender}}},
+		Amount:     map[string]shared.TransactionAmount{},
+		Additional: map[string][]string{"lock_id": {strconv.FormatUint(m.LockID, 10)}},
+	}
+	if m.ValAddr != "" {
+		se.Node["validator"] = []shared.Account{{ID: m.ValAddr}}
+	}
+
+	err = rewardsToSub(&se, lg, m.Sender, true)
+	return se, err
+}
+
+// LockTokens returns the owner and the locked coins of lockup.MsgLockTokens sdk messages
+func LockTokens(msg []byte) (owner string, coins sdk.Coins, err error) {
+	m := &msgLockTokens{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return "", nil, util.Errorf(util.ErrMalformedMessage, "Not a lock_tokens type: %w", err)
+	}
+	return m.Owner, m.Coins, nil
+}
+
+// BeginUnlocking returns the owner and the unlocked coins of lockup.MsgBeginUnlocking sdk messages
+func BeginUnlocking(msg []byte) (owner string, coins sdk.Coins, err error) {
+	m := &msgBeginUnlocking{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return "", nil, util.Errorf(util.ErrMalformedMessage, "Not a begin_unlocking type: %w", err)
+	}
+	return m.Owner, m.Coins, nil
+}
+
+// Superfluid returns the sender and the validator (empty when not in the message) of superfluid sdk messages
+func Superfluid(msg []byte) (sender, validator string, err error) {
+	m := &msgSuperfluid{}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return "", "", util.Errorf(util.ErrMalformedMessage, "Not a superfluid type: %w", err)
+	}
+	return m.Sender, m.ValAddr, nil
+}
+
+// Reward is a staking reward withdrawn while executing the message
+type Reward struct {
+	// Delegator is the account the reward is withdrawn by, empty when it's not in the event
+	Delegator string
+	Validator string
+	Amount    string
+}
+
+// WithdrawnRewards returns rewards from the withdraw_rewards events. For superfluid these are withdrawn
+// by the intermediary account of the lock, not by the message sender.
+func WithdrawnRewards(lg sdk.ABCIMessageLog) (rewards []Reward) {
+	for _, attrs := range eventsByType(lg, "withdraw_rewards") {
+		if attrs["amount"] == "" {
+			continue
+		}
+		rewards = append(rewards, Reward{Delegator: attrs["delegator"], Validator: attrs["validator"], Amount: attrs["amount"]})
+	}
+	return rewards
+}
+
+// SenderRewards splits withdrawn rewards to the ones of the message sender and of other accounts. The
+// intermediary account pools the superfluid stake of all the users of the validator and denom, so its
+// rewards are not claimed by the sender. Events before sdk v0.46 have no delegator, these rewards are
+// the sender's unless the message is superfluid, which withdraws rewards of the intermediary account only.
+func SenderRewards(rewards []Reward, sender string, superfluid bool) (own, other []Reward) {
+	for _, r := range rewards {
+		if r.Delegator == sender || (r.Delegator == "" && !superfluid) {
+			own = append(own, r)
+		} else {
+			other = append(other, r)
+		}
+	}
+	return own, other
+}
+
+// rewardsToSub adds rewards withdrawn by the sender while executing the message, rewards of other accounts
+// (the intermediary account) are added to Sub as "intermediary_withdraw_rewards" not claimed by the sender
+func rewardsToSub(se *shared.SubsetEvent, lg sdk.ABCIMessageLog, sender string, superfluid bool) error {
+	rewards := WithdrawnRewards(lg)
+	for _, r := range rewards {
+		if _, ok := se.Node["validator"]; !ok && r.Validator != "" {
+			se.Node["validator"] = []shared.Account{{ID: r.Validator}}
+		}
+	}
+
+	own, other := SenderRewards(rewards, sender, superfluid)
+	var amounts []shared.TransactionAmount
+	for _, r := range own {
+		a, err := parseAmounts(r.Amount)
+		if err != nil {
+			return err
+		}
+		amounts = append(amounts, a...)
+		se.Additional["reward_validator"] = append(se.Additional["reward_validator"], r.Validator)
+	}
+	amountsToSub(se, "reward", amounts)
+
+	for _, r := range other {
+		a, err := parseAmounts(r.Amount)
+		if err != nil {
+			return err
+		}
+		sub := shared.SubsetEvent{
+			Type:   []string{"intermediary_withdraw_rewards"},
+			Module: "distribution",
+			Node:   map[string][]shared.Account{},
+			Amount: map[string]shared.TransactionAmount{},
+		}
+		if r.Delegator != "" {
+			sub.Node["delegator"] = []shared.Account{{ID: r.Delegator}}
+		}
+		if r.Validator != "" {
+			sub.Node["validator"] = []shared.Account{{ID: r.Validator}}
+		}
+		amountsToSub(&sub, "reward", a)
+		se.Sub = append(se.Sub, sub)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package osmosis_mapper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+func TestOsmosisLockTokens(t *testing.T) {
+	msg, err := proto.Marshal(&msgLockTokens{
+		Owner:    "osmo1owner",
+		Duration: 14 * 24 * time.Hour,
+		Coins:    sdk.NewCoins(sdk.NewInt64Coin("gamm/pool/1", 5000)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lg := sdk.ABCIMessageLog{Events: []sdk.StringEvent{
+		{Type: "lock_tokens", Attributes: []sdk.Attribute{
+			{Key: "period_lock_id", Value: "911"}, {Key: "owner", Value: "osmo1owner"}, {Key: "amount", Value: "5000gamm/pool/1"}, {Key: "duration", Value: "336h0m0s"},
+		}},
+	}}
+
+	se, err := OsmosisLockTokens(msg, lg)
+	if err != nil {
+		t.Fatalf("OsmosisLockTokens() error = %v", err)
+	}
+
+	if am := se.Amount["lock"]; am.Text+am.Currency != "5000gamm/pool/1" {
+		t.Errorf("Amount[lock] = %+v", am)
+	}
+	if !reflect.DeepEqual(se.Additional["duration"], []string{"336h0m0s"}) || !reflect.DeepEqual(se.Additional["lock_id"], []string{"911"}) {
+		t.Errorf("unexpected additional %v", se.Additional)
+	}
+}
+
+func TestOsmosisSuperfluidDelegate(t *testing.T) {
+	msg, err := proto.Marshal(&msgSuperfluid{Sender: "osmo1sender", LockID: 911, ValAddr: "osmovaloper1validator"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lg := sdk.ABCIMessageLog{Events: []sdk.StringEvent{
+		{Type: "withdraw_rewards", Attributes: []sdk.Attribute{
+			{Key: "amount", Value: "25uosmo"}, {Key: "validator", Value: "osmovaloper1validator"}, {Key: "delegator", Value: "osmo1intermediary"},
+		}},
+	}}
+
+	se, err := OsmosisSuperfluidDelegate(msg, lg)
+	if err != nil {
+		t.Fatalf("OsmosisSuperfluidDelegate() error = %v", err)
+	}
+
+	if se.Node["validator"][0].ID != "osmovaloper1validator" || se.Node["delegator"][0].ID != "osmo1sender" {
+		t.Errorf("unexpected nodes %v", se.Node)
+	}
+	// rewards of the intermediary account are not claimed by the sender
+	if am, ok := se.Amount["reward"]; ok {
+		t.Errorf("Amount[reward] = %+v, want none", am)
+	}
+	if len(se.Sub) != 1 {
+		t.Fatalf("expected intermediary rewards subevent, got %+v", se.Sub)
+	}
+	sub := se.Sub[0]
+	if sub.Type[0] != "intermediary_withdraw_rewards" || sub.Node["delegator"][0].ID != "osmo1intermediary" {
+		t.Errorf("unexpected intermediary rewards subevent %+v", sub)
+	}
+	if am := sub.Amount["reward"]; am.Text+am.Currency != "25uosmo" {
+		t.Errorf("intermediary Amount[reward] = %+v", am)
+	}
+}
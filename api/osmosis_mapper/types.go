@@ -0,0 +1,117 @@
+package osmosis_mapper
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// osmosis messages, osmosis types can't be used as they require the osmosis fork of cosmos-sdk.
+// Only the fields used by the mappers are present, the rest is skipped while decoding.
+
+// swapRoute is both SwapAmountInRoute (with token out denom) and SwapAmountOutRoute (with token in denom)
+type swapRoute struct {
+	PoolId uint64 `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3"`
+	Denom  string `protobuf:"bytes,2,opt,name=denom,proto3"`
+}
+
+func (m *swapRoute) Reset()         { *m = swapRoute{} }
+func (m *swapRoute) String() string { return proto.CompactTextString(m) }
+func (*swapRoute) ProtoMessage()    {}
+
+type msgSwapExactAmountIn struct {
+	Sender            string       `protobuf:"bytes,1,opt,name=sender,proto3"`
+	Routes            []*swapRoute `protobuf:"bytes,2,rep,name=routes,proto3"`
+	TokenIn           sdk.Coin     `protobuf:"bytes,3,opt,name=token_in,json=tokenIn,proto3"`
+	TokenOutMinAmount string       `protobuf:"bytes,4,opt,name=token_out_min_amount,json=tokenOutMinAmount,proto3"`
+}
+
+func (m *msgSwapExactAmountIn) Reset()         { *m = msgSwapExactAmountIn{} }
+func (m *msgSwapExactAmountIn) String() string { return proto.CompactTextString(m) }
+func (*msgSwapExactAmountIn) ProtoMessage()    {}
+
+type msgSwapExactAmountOut struct {
+	Sender           string       `protobuf:"bytes,1,opt,name=sender,proto3"`
+	Routes           []*swapRoute `protobuf:"bytes,2,rep,name=routes,proto3"`
+	TokenInMaxAmount string       `protobuf:"bytes,3,opt,name=token_in_max_amount,json=tokenInMaxAmount,proto3"`
+	TokenOut         sdk.Coin     `protobuf:"bytes,4,opt,name=token_out,json=tokenOut,proto3"`
+}
+
+func (m *msgSwapExactAmountOut) Reset()         { *m = msgSwapExactAmountOut{} }
+func (m *msgSwapExactAmountOut) String() string { return proto.CompactTextString(m) }
+func (*msgSwapExactAmountOut) ProtoMessage()    {}
+
+// msgJoinExitPool is both MsgJoinPool (with share out amount and token in maxs)
+// and MsgExitPool (with share in amount and token out mins)
+type msgJoinExitPool struct {
+	Sender      string    `protobuf:"bytes,1,opt,name=sender,proto3"`
+	PoolId      uint64    `protobuf:"varint,2,opt,name=pool_id,json=poolId,proto3"`
+	ShareAmount string    `protobuf:"bytes,3,opt,name=share_amount,json=shareAmount,proto3"`
+	TokenLimits sdk.Coins `protobuf:"bytes,4,rep,name=token_limits,json=tokenLimits,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins"`
+}
+
+func (m *msgJoinExitPool) Reset()         { *m = msgJoinExitPool{} }
+func (m *msgJoinExitPool) String() string { return proto.CompactTextString(m) }
+func (*msgJoinExitPool) ProtoMessage()    {}
+
+// msgJoinExitSwapExtern is both MsgJoinSwapExternAmountIn (with token in and share out min amount)
+// and MsgExitSwapExternAmountOut (with token out and share in max amount)
+type msgJoinExitSwapExtern struct {
+	Sender      string   `protobuf:"bytes,1,opt,name=sender,proto3"`
+	PoolId      uint64   `protobuf:"varint,2,opt,name=pool_id,json=poolId,proto3"`
+	Token       sdk.Coin `protobuf:"bytes,3,opt,name=token,proto3"`
+	ShareAmount string   `protobuf:"bytes,4,opt,name=share_amount,json=shareAmount,proto3"`
+}
+
+func (m *msgJoinExitSwapExtern) Reset()         { *m = msgJoinExitSwapExtern{} }
+func (m *msgJoinExitSwapExtern) String() string { return proto.CompactTextString(m) }
+func (*msgJoinExitSwapExtern) ProtoMessage()    {}
+
+// msgJoinExitSwapShare is both MsgJoinSwapShareAmountOut (with token in denom, share out amount and token in max amount)
+// and MsgExitSwapShareAmountIn (with token out denom, share in amount and token out min amount)
+type msgJoinExitSwapShare struct {
+	Sender      string `protobuf:"bytes,1,opt,name=sender,proto3"`
+	PoolId      uint64 `protobuf:"varint,2,opt,name=pool_id,json=poolId,proto3"`
+	TokenDenom  string `protobuf:"bytes,3,opt,name=token_denom,json=tokenDenom,proto3"`
+	ShareAmount string `protobuf:"bytes,4,opt,name=share_amount,json=shareAmount,proto3"`
+	TokenLimit  string `protobuf:"bytes,5,opt,name=token_limit,json=tokenLimit,proto3"`
+}
+
+func (m *msgJoinExitSwapShare) Reset()         { *m = msgJoinExitSwapShare{} }
+func (m *msgJoinExitSwapShare) String() string { return proto.CompactTextString(m) }
+func (*msgJoinExitSwapShare) ProtoMessage()    {}
+
+// msgLockTokens is osmosis.lockup.MsgLockTokens
+type msgLockTokens struct {
+	Owner    string        `protobuf:"bytes,1,opt,name=owner,proto3"`
+	Duration time.Duration `protobuf:"bytes,2,opt,name=duration,proto3,stdduration"`
+	Coins    sdk.Coins     `protobuf:"bytes,3,rep,name=coins,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins"`
+}
+
+func (m *msgLockTokens) Reset()         { *m = msgLockTokens{} }
+func (m *msgLockTokens) String() string { return proto.CompactTextString(m) }
+func (*msgLockTokens) ProtoMessage()    {}
+
+// msgBeginUnlocking is osmosis.lockup.MsgBeginUnlocking, also used for MsgBeginUnlockingAll (with Owner only)
+type msgBeginUnlocking struct {
+	Owner string    `protobuf:"bytes,1,opt,name=owner,proto3"`
+	ID    uint64    `protobuf:"varint,2,opt,name=ID,proto3"`
+	Coins sdk.Coins `protobuf:"bytes,3,rep,name=coins,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins"`
+}
+
+func (m *msgBeginUnlocking) Reset()         { *m = msgBeginUnlocking{} }
+func (m *msgBeginUnlocking) String() string { return proto.CompactTextString(m) }
+func (*msgBeginUnlocking) ProtoMessage()    {}
+
+// msgSuperfluid is osmosis.superfluid.MsgSuperfluidDelegate,
+// also used for MsgSuperfluidUndelegate and MsgSuperfluidUnbondLock (without ValAddr)
+type msgSuperfluid struct {
+	Sender  string `protobuf:"bytes,1,opt,name=sender,proto3"`
+	LockID  uint64 `protobuf:"varint,2,opt,name=lock_id,json=lockId,proto3"`
+	ValAddr string `protobuf:"bytes,3,opt,name=val_addr,json=valAddr,proto3"`
+}
+
+func (m *msgSuperfluid) Reset()         { *m = msgSuperfluid{} }
+func (m *msgSuperfluid) String() string { return proto.CompactTextString(m) }
+func (*msgSuperfluid) ProtoMessage()    {}
@@ -0,0 +1,383 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	codec_types "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/figment-networks/indexing-engine/structs"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
+
+	"github.com/figment-networks/ni-cosmoslib/api/mapper"
+	"github.com/figment-networks/ni-cosmoslib/api/osmosis_mapper"
+	"github.com/figment-networks/ni-cosmoslib/api/tendermint_mapper"
+)
+
+// Handler transforms a single raw sdk message (and its log) to SubsetEvent
+type Handler func(ma *mapper.Mapper, msg []byte, lg types.ABCIMessageLog) (structs.SubsetEvent, error)
+
+// Registry holds message handlers keyed by full TypeUrl (ie "/cosmos.bank.v1beta1.MsgSend")
+// with a fallback on route and type (ie "bank" and "MsgSend") for messages that
+// are the same across versions.
+type Registry struct {
+	name string
+
+	lock      sync.RWMutex
+	byTypeURL map[string]Handler
+	byRoute   map[string]map[string]Handler
+	// since holds the minimal cosmos-sdk versions of handlers by TypeUrl or "<route>/<msgType>"
+	since map[string]string
+}
+
+// NewRegistry returns an empty registry, name is used in the error messages (ie "cosmos")
+func NewRegistry(name string) *Registry {
+	return &Registry{
+		name:      name,
+		byTypeURL: make(map[string]Handler),
+		byRoute:   make(map[string]map[string]Handler),
+		since:     make(map[string]string),
+	}
+}
+
+// Register sets the handler for the full TypeUrl, overriding any previous one
+func (r *Registry) Register(typeURL string, h Handler) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.byTypeURL[typeURL] = h
+	delete(r.since, typeURL)
+}
+
+// RegisterRoute sets the handler for every TypeUrl in the format "/<any>.<route>.<any>.<msgType>"
+// that does not have its own handler registered by Register
+func (r *Registry) RegisterRoute(route, msgType string, h Handler) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	handlers, ok := r.byRoute[route]
+	if !ok {
+		handlers = make(map[string]Handler)
+		r.byRoute[route] = handlers
+	}
+	handlers[msgType] = h
+	delete(r.since, route+"/"+msgType)
+}
+
+// RegisterSince is Register for messages that exist from the cosmos-sdk version (ie "v0.46")
+func (r *Registry) RegisterSince(sdkVersion, typeURL string, h Handler) {
+	r.Register(typeURL, h)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.since[typeURL] = sdkVersion
+}
+
+// RegisterRouteSince is RegisterRoute for messages that exist from the cosmos-sdk version (ie "v0.46")
+func (r *Registry) RegisterRouteSince(sdkVersion, route, msgType string, h Handler) {
+	r.RegisterRoute(route, msgType, h)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.since[route+"/"+msgType] = sdkVersion
+}
+
+// Handler returns handler for the given TypeUrl
+func (r *Registry) Handler(typeURL string) (h Handler, ok bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	h, _, ok = r.handler(typeURL)
+	return h, ok
+}
+
+// HandlerAt returns handler for the given TypeUrl skipping handlers of messages that don't exist in the upgrade
+func (r *Registry) HandlerAt(typeURL string, u util.Upgrade) (h Handler, ok bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	h, key, ok := r.handler(typeURL)
+	if !ok {
+		return nil, false
+	}
+	if since, ok := r.since[key]; ok && !u.SDKAtLeast(since) {
+		return nil, false
+	}
+	return h, true
+}
+
+func (r *Registry) handler(typeURL string) (h Handler, key string, ok bool) {
+	if h, ok = r.byTypeURL[typeURL]; ok {
+		return h, typeURL, ok
+	}
+
+	// TypeUrl must be in the format "/cosmos.bank.v1beta1.MsgSend"
+	tPath := strings.Split(typeURL, ".")
+	if len(tPath) != 4 {
+		return nil, "", false
+	}
+	h, ok = r.byRoute[tPath[1]][tPath[3]]
+	return h, tPath[1] + "/" + tPath[3], ok
+}
+
+// Merge copies handlers of the other registry, overriding the existing ones
+func (r *Registry) Merge(o *Registry) {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+	for typeURL, h := range o.byTypeURL {
+		r.Register(typeURL, h)
+	}
+	for route, handlers := range o.byRoute {
+		for msgType, h := range handlers {
+			r.RegisterRoute(route, msgType, h)
+		}
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for key, since := range o.since {
+		r.since[key] = since
+	}
+}
+
+// Map transforms the message to SubsetEvent using registered handlers
+func (r *Registry) Map(ma *mapper.Mapper, m *codec_types.Any, lg types.ABCIMessageLog) (ev structs.SubsetEvent, err error) {
+	h, ok := r.Handler(m.TypeUrl)
+	if !ok {
+		return ev, withMessage(fmt.Errorf("problem with %s event %s: %w", r.name, m.TypeUrl, util.ErrUnknownMessageType), m, lg)
+	}
+	// for mapper = nil use the default
+	if ma == nil {
+		ma = defaultMapper
+	}
+	ev, err = h(ma, m.Value, lg)
+	return ev, withMessage(err, m, lg)
+}
+
+// MapAt transforms the message to SubsetEvent using handlers of messages that exist in the upgrade
+func (r *Registry) MapAt(ma *mapper.Mapper, m *codec_types.Any, lg types.ABCIMessageLog, u util.Upgrade) (ev structs.SubsetEvent, err error) {
+	h, ok := r.HandlerAt(m.TypeUrl, u)
+	if !ok {
+		return ev, withMessage(fmt.Errorf("problem with %s event %s (sdk %s): %w", r.name, m.TypeUrl, u.SDKVersion, util.ErrUnknownMessageType), m, lg)
+	}
+	if ma == nil {
+		ma = defaultMapper
+	}
+	mu := *ma
+	mu.Upgrade = &u
+	ev, err = h(&mu, m.Value, lg)
+	return ev, withMessage(err, m, lg)
+}
+
+// mapCarried maps messages carried inside other messages, at the upgrade of the outer message when it's known
+func (r *Registry) mapCarried(ma *mapper.Mapper, m *codec_types.Any, lg types.ABCIMessageLog) (structs.SubsetEvent, error) {
+	if ma != nil && ma.Upgrade != nil {
+		return r.MapAt(ma, m, lg, *ma.Upgrade)
+	}
+	return r.Map(ma, m, lg)
+}
+
+// withMessage adds the message details to the error as util.MappingError
+func withMessage(err error, m *codec_types.Any, lg types.ABCIMessageLog) error {
+	if err == nil {
+		return nil
+	}
+	// TypeUrl is in the format "/cosmos.bank.v1beta1.MsgSend"
+	tPath := strings.Split(m.TypeUrl, ".")
+	var module string
+	if len(tPath) > 2 {
+		module = tPath[1]
+	}
+	return util.WithMessage(err, module, tPath[len(tPath)-1], m.TypeUrl, int(lg.MsgIndex))
+}
+
+// AddSubEvent transforms the message to SubsetEvent and adds it to the provided TransactionEvent struct
+func (r *Registry) AddSubEvent(tev *structs.TransactionEvent, m *codec_types.Any, lg types.ABCIMessageLog, ma *mapper.Mapper) (err error) {
+	ev, err := r.Map(ma, m, lg)
+	addSub(tev, ev)
+	return err
+}
+
+// AddSubEventAt is AddSubEvent for the message of a transaction at the height, the upgrade
+// schedule decides which messages exist at the height
+func (r *Registry) AddSubEventAt(tev *structs.TransactionEvent, m *codec_types.Any, lg types.ABCIMessageLog, ma *mapper.Mapper, height uint64, schedule util.UpgradeSchedule) (err error) {
+	ev, err := r.MapAt(ma, m, lg, schedule.At(height))
+	addSub(tev, ev)
+	return err
+}
+
+func addSub(tev *structs.TransactionEvent, ev structs.SubsetEvent) {
+	if len(ev.Type) > 0 {
+		tev.Sub = append(tev.Sub, ev)
+		tev.Kind = ev.Type[0]
+	}
+}
+
+// withoutLog adapts mapper methods that don't need the message log to Handler
+func withoutLog(f func(ma *mapper.Mapper, msg []byte) (structs.SubsetEvent, error)) Handler {
+	return func(ma *mapper.Mapper, msg []byte, _ types.ABCIMessageLog) (structs.SubsetEvent, error) {
+		return f(ma, msg)
+	}
+}
+
+// withoutMapperWithLog adapts package level mappers that need the message log to Handler
+func withoutMapperWithLog(f func(msg []byte, lg types.ABCIMessageLog) (structs.SubsetEvent, error)) Handler {
+	return func(_ *mapper.Mapper, msg []byte, lg types.ABCIMessageLog) (structs.SubsetEvent, error) {
+		return f(msg, lg)
+	}
+}
+
+// withoutMapper adapts package level mappers to Handler
+func withoutMapper(f func(msg []byte) (structs.SubsetEvent, error)) Handler {
+	return func(_ *mapper.Mapper, msg []byte, _ types.ABCIMessageLog) (structs.SubsetEvent, error) {
+		return f(msg)
+	}
+}
+
+// authzExec maps authz.MsgExec re-dispatching every executed message through the registry
+func (r *Registry) authzExec(ma *mapper.Mapper, msg []byte, lg types.ABCIMessageLog) (structs.SubsetEvent, error) {
+	return ma.AuthzExecToSub(msg, lg, func(m *codec_types.Any, lg types.ABCIMessageLog) (structs.SubsetEvent, error) {
+		return r.mapCarried(ma, m, lg)
+	})
+}
+
+// govV1SubmitProposal maps gov v1.MsgSubmitProposal decoding proposal messages through the registry
+func (r *Registry) govV1SubmitProposal(ma *mapper.Mapper, msg []byte, lg types.ABCIMessageLog) (structs.SubsetEvent, error) {
+	return ma.GovV1SubmitProposalToSub(msg, lg, func(m *codec_types.Any, lg types.ABCIMessageLog) (structs.SubsetEvent, error) {
+		return r.mapCarried(ma, m, lg)
+	})
+}
+
+// groupSubmitProposal maps group.MsgSubmitProposal decoding proposal messages through the registry
+func (r *Registry) groupSubmitProposal(ma *mapper.Mapper, msg []byte, lg types.ABCIMessageLog) (structs.SubsetEvent, error) {
+	return ma.GroupSubmitProposalToSub(msg, lg, func(m *codec_types.Any, lg types.ABCIMessageLog) (structs.SubsetEvent, error) {
+		return r.mapCarried(ma, m, lg)
+	})
+}
+
+// NewDefaultRegistry returns registry with all the cosmos-sdk handlers supported by mapper.Mapper
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry("cosmos")
+
+	r.RegisterRoute("authz", "MsgGrant", withoutLog((*mapper.Mapper).AuthzGrantToSub))
+	r.RegisterRoute("authz", "MsgExecResponse", withoutLog((*mapper.Mapper).AuthzExecResponseToSub))
+	r.RegisterRoute("authz", "MsgExec", r.authzExec)
+	r.RegisterRoute("authz", "MsgGrantResponse", withoutLog((*mapper.Mapper).AuthzGrantResponseToSub))
+	r.RegisterRoute("authz", "MsgRevoke", withoutLog((*mapper.Mapper).AuthzMsgRevokeToSub))
+	r.RegisterRoute("authz", "MsgRevokeResponse", withoutLog((*mapper.Mapper).AuthzMsgRevokeResponseToSub))
+
+	r.RegisterRoute("bank", "MsgSend", (*mapper.Mapper).BankSendToSub)
+	r.RegisterRoute("bank", "MsgMultiSend", (*mapper.Mapper).BankMultisendToSub)
+
+	r.RegisterRoute("crisis", "MsgVerifyInvariant", withoutLog((*mapper.Mapper).CrisisVerifyInvariantToSub))
+
+	r.RegisterRoute("distribution", "MsgWithdrawValidatorCommission", (*mapper.Mapper).DistributionWithdrawValidatorCommissionToSub)
+	r.RegisterRoute("distribution", "MsgSetWithdrawAddress", withoutLog((*mapper.Mapper).DistributionSetWithdrawAddressToSub))
+	r.RegisterRoute("distribution", "MsgWithdrawDelegatorReward", (*mapper.Mapper).DistributionWithdrawDelegatorRewardToSub)
+	r.RegisterRoute("distribution", "MsgFundCommunityPool", withoutLog((*mapper.Mapper).DistributionFundCommunityPoolToSub))
+
+	r.RegisterRoute("evidence", "MsgSubmitEvidence", withoutLog((*mapper.Mapper).EvidenceSubmitEvidenceToSub))
+
+	r.RegisterRoute("feegrant", "MsgGrantAllowance", withoutLog((*mapper.Mapper).FeegrantGrantAllowance))
+	r.RegisterRoute("feegrant", "MsgGrantAllowanceResponse", withoutLog((*mapper.Mapper).FeegrantGrantAllowanceResponse))
+	r.RegisterRoute("feegrant", "MsgRevokeAllowance", withoutLog((*mapper.Mapper).FeegrantRevokeAllowance))
+	r.RegisterRoute("feegrant", "MsgRevokeAllowanceResponse", withoutLog((*mapper.Mapper).FeegrantRevokeAllowanceResponse))
+
+	r.RegisterRoute("gov", "MsgDeposit", (*mapper.Mapper).GovDepositToSub)
+	r.RegisterRoute("gov", "MsgVote", withoutLog((*mapper.Mapper).GovVoteToSub))
+	r.RegisterRoute("gov", "MsgSubmitProposal", (*mapper.Mapper).GovSubmitProposalToSub)
+	r.RegisterRoute("gov", "MsgVoteWeighted", (*mapper.Mapper).GovMsgVoteWeighted)
+
+	// gov v1 shares the route and type with v1beta1 but has a different layout
+	r.RegisterSince("v0.46", "/cosmos.gov.v1.MsgDeposit", (*mapper.Mapper).GovV1DepositToSub)
+	r.RegisterSince("v0.46", "/cosmos.gov.v1.MsgVote", withoutLog((*mapper.Mapper).GovV1VoteToSub))
+	r.RegisterSince("v0.46", "/cosmos.gov.v1.MsgSubmitProposal", r.govV1SubmitProposal)
+	r.RegisterSince("v0.46", "/cosmos.gov.v1.MsgVoteWeighted", (*mapper.Mapper).GovV1VoteWeightedToSub)
+	r.RegisterSince("v0.46", "/cosmos.gov.v1.MsgExecLegacyContent", (*mapper.Mapper).GovV1ExecLegacyContentToSub)
+
+	r.RegisterRouteSince("v0.46", "group", "MsgCreateGroup", (*mapper.Mapper).GroupCreateGroupToSub)
+	r.RegisterRouteSince("v0.46", "group", "MsgUpdateGroupMembers", withoutLog((*mapper.Mapper).GroupUpdateGroupMembersToSub))
+	r.RegisterRouteSince("v0.46", "group", "MsgCreateGroupPolicy", (*mapper.Mapper).GroupCreateGroupPolicyToSub)
+	r.RegisterRouteSince("v0.46", "group", "MsgSubmitProposal", r.groupSubmitProposal)
+	r.RegisterRouteSince("v0.46", "group", "MsgVote", (*mapper.Mapper).GroupVoteToSub)
+	r.RegisterRouteSince("v0.46", "group", "MsgExec", (*mapper.Mapper).GroupExecToSub)
+	r.RegisterRouteSince("v0.46", "group", "MsgLeaveGroup", withoutLog((*mapper.Mapper).GroupLeaveGroupToSub))
+
+	r.RegisterRoute("slashing", "MsgUnjail", withoutLog((*mapper.Mapper).SlashingUnjailToSub))
+
+	r.RegisterRoute("vesting", "MsgCreateVestingAccount", (*mapper.Mapper).VestingMsgCreateVestingAccountToSub)
+
+	r.RegisterRoute("wasm", "MsgStoreCode", (*mapper.Mapper).WasmStoreCodeToSub)
+	r.RegisterRoute("wasm", "MsgInstantiateContract", (*mapper.Mapper).WasmInstantiateContractToSub)
+	r.RegisterRoute("wasm", "MsgInstantiateContract2", (*mapper.Mapper).WasmInstantiateContract2ToSub)
+	r.RegisterRoute("wasm", "MsgExecuteContract", (*mapper.Mapper).WasmExecuteContractToSub)
+	r.RegisterRoute("wasm", "MsgMigrateContract", (*mapper.Mapper).WasmMigrateContractToSub)
+	r.RegisterRoute("wasm", "MsgUpdateAdmin", withoutLog((*mapper.Mapper).WasmUpdateAdminToSub))
+	r.RegisterRoute("wasm", "MsgClearAdmin", withoutLog((*mapper.Mapper).WasmClearAdminToSub))
+
+	r.RegisterRoute("staking", "MsgUndelegate", (*mapper.Mapper).StakingUndelegateToSub)
+	r.RegisterRoute("staking", "MsgEditValidator", withoutLog((*mapper.Mapper).StakingEditValidatorToSub))
+	r.RegisterRoute("staking", "MsgCreateValidator", withoutLog((*mapper.Mapper).StakingCreateValidatorToSub))
+	r.RegisterRoute("staking", "MsgDelegate", (*mapper.Mapper).StakingDelegateToSub)
+	r.RegisterRoute("staking", "MsgBeginRedelegate", (*mapper.Mapper).StakingBeginRedelegateToSub)
+	r.RegisterRoute("staking", "MsgCancelUnbondingDelegation", (*mapper.Mapper).StakingCancelUnbondingDelegationToSub)
+	// liquid staking (LSM) of the cosmos hub
+	r.RegisterRoute("staking", "MsgTokenizeShares", (*mapper.Mapper).StakingTokenizeSharesToSub)
+	r.RegisterRoute("staking", "MsgRedeemTokensForShares", (*mapper.Mapper).StakingRedeemTokensForSharesToSub)
+	r.RegisterRoute("staking", "MsgTransferTokenizeShareRecord", withoutLog((*mapper.Mapper).StakingTransferTokenizeShareRecordToSub))
+	r.RegisterRoute("staking", "MsgValidatorBond", withoutLog((*mapper.Mapper).StakingValidatorBondToSub))
+
+	return r
+}
+
+// NewChainRegistry returns the default registry extended with the chain specific
+// module handlers enabled in the profile ("liquidity", "osmosis")
+func NewChainRegistry(p util.ChainProfile) *Registry {
+	r := NewDefaultRegistry()
+	if p.Name != "" {
+		r.name = p.Name
+	}
+	if p.HasModule("liquidity") {
+		r.Merge(NewTendermintRegistry())
+	}
+	if p.HasModule("osmosis") {
+		r.Merge(NewOsmosisRegistry())
+	}
+	return r
+}
+
+// NewTendermintRegistry returns registry with the tendermint liquidity module handlers
+func NewTendermintRegistry() *Registry {
+	r := NewRegistry("tendermint")
+
+	// see types https://github.com/Gravity-Devs/liquidity/blob/44220af8ebd5b664768b4098a2159b75ca02df8a/x/liquidity/spec/04_messages.md
+	r.RegisterRoute("liquidity", "MsgCreatePool", withoutMapper(tendermint_mapper.TendermintCreatePool))
+	r.RegisterRoute("liquidity", "MsgDepositWithinBatch", withoutMapper(tendermint_mapper.TendermintDepositWithinBatch))
+	r.RegisterRoute("liquidity", "MsgWithdrawWithinBatch", withoutMapper(tendermint_mapper.TendermintWithdrawWithinBatch))
+	r.RegisterRoute("liquidity", "MsgSwapWithinBatch", withoutMapper(tendermint_mapper.TendermintSwapWithinBatch))
+
+	return r
+}
+
+// NewOsmosisRegistry returns registry with the osmosis gamm, poolmanager, lockup and superfluid module handlers
+func NewOsmosisRegistry() *Registry {
+	r := NewRegistry("osmosis")
+
+	r.RegisterRoute("gamm", "MsgSwapExactAmountIn", withoutMapperWithLog(osmosis_mapper.OsmosisSwapExactAmountIn))
+	r.RegisterRoute("gamm", "MsgSwapExactAmountOut", withoutMapperWithLog(osmosis_mapper.OsmosisSwapExactAmountOut))
+	r.RegisterRoute("gamm", "MsgJoinPool", withoutMapperWithLog(osmosis_mapper.OsmosisJoinPool))
+	r.RegisterRoute("gamm", "MsgExitPool", withoutMapperWithLog(osmosis_mapper.OsmosisExitPool))
+	r.RegisterRoute("gamm", "MsgJoinSwapExternAmountIn", withoutMapperWithLog(osmosis_mapper.OsmosisJoinSwapExternAmountIn))
+	r.RegisterRoute("gamm", "MsgExitSwapExternAmountOut", withoutMapperWithLog(osmosis_mapper.OsmosisExitSwapExternAmountOut))
+	r.RegisterRoute("gamm", "MsgJoinSwapShareAmountOut", withoutMapperWithLog(osmosis_mapper.OsmosisJoinSwapShareAmountOut))
+	r.RegisterRoute("gamm", "MsgExitSwapShareAmountIn", withoutMapperWithLog(osmosis_mapper.OsmosisExitSwapShareAmountIn))
+
+	r.RegisterRoute("poolmanager", "MsgSwapExactAmountIn", withoutMapperWithLog(osmosis_mapper.OsmosisPoolManagerSwapExactAmountIn))
+	r.RegisterRoute("poolmanager", "MsgSwapExactAmountOut", withoutMapperWithLog(osmosis_mapper.OsmosisPoolManagerSwapExactAmountOut))
+
+	// lockup and superfluid TypeUrls have no version (ie "/osmosis.lockup.MsgLockTokens")
+	r.Register("/osmosis.lockup.MsgLockTokens", withoutMapperWithLog(osmosis_mapper.OsmosisLockTokens))
+	r.Register("/osmosis.lockup.MsgBeginUnlocking", withoutMapperWithLog(osmosis_mapper.OsmosisBeginUnlocking))
+	r.Register("/osmosis.superfluid.MsgSuperfluidDelegate", withoutMapperWithLog(osmosis_mapper.OsmosisSuperfluidDelegate))
+	r.Register("/osmosis.superfluid.MsgSuperfluidUndelegate", withoutMapperWithLog(osmosis_mapper.OsmosisSuperfluidUndelegate))
+	r.Register("/osmosis.superfluid.MsgSuperfluidUnbondLock", withoutMapperWithLog(osmosis_mapper.OsmosisSuperfluidUnbondLock))
+
+	return r
+}
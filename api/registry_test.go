@@ -0,0 +1,410 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	basev1beta1 "cosmossdk.io/api/cosmos/base/v1beta1"
+	govv1 "cosmossdk.io/api/cosmos/gov/v1"
+	codec_types "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	distribution "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	gov "github.com/cosmos/cosmos-sdk/x/gov/types"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/figment-networks/indexing-engine/structs"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
+
+	"github.com/figment-networks/ni-cosmoslib/api/mapper"
+)
+
+func TestRegistry_AddSubEvent(t *testing.T) {
+	custom := func(ma *mapper.Mapper, msg []byte, lg types.ABCIMessageLog) (structs.SubsetEvent, error) {
+		return structs.SubsetEvent{Type: []string{"custom"}, Module: "custom"}, nil
+	}
+
+	tests := []struct {
+		name     string
+		typeURL  string
+		register func(r *Registry)
+		wantKind string
+		wantErr  error
+	}{
+		{
+			name:    "unknown_type",
+			typeURL: "/osmosis.gamm.v1beta1.MsgSwapExactAmountIn",
+			wantErr: util.ErrUnknownMessageType,
+		},
+		{
+			name:    "full_type_url",
+			typeURL: "/osmosis.gamm.v1beta1.MsgSwapExactAmountIn",
+			register: func(r *Registry) {
+				r.Register("/osmosis.gamm.v1beta1.MsgSwapExactAmountIn", custom)
+			},
+			wantKind: "custom",
+		},
+		{
+			name:    "full_type_url_different_length",
+			typeURL: "/osmosis.gamm.poolmodels.balancer.v1beta1.MsgCreateBalancerPool",
+			register: func(r *Registry) {
+				r.Register("/osmosis.gamm.poolmodels.balancer.v1beta1.MsgCreateBalancerPool", custom)
+			},
+			wantKind: "custom",
+		},
+		{
+			name:    "route_fallback",
+			typeURL: "/kava.cdp.v1beta1.MsgDeposit",
+			register: func(r *Registry) {
+				r.RegisterRoute("cdp", "MsgDeposit", custom)
+			},
+			wantKind: "custom",
+		},
+		{
+			name:    "full_type_url_overrides_route",
+			typeURL: "/cosmos.slashing.v1beta1.MsgUnjail",
+			register: func(r *Registry) {
+				r.Register("/cosmos.slashing.v1beta1.MsgUnjail", custom)
+			},
+			wantKind: "custom",
+		},
+		{
+			name:     "default_handler",
+			typeURL:  "/cosmos.slashing.v1beta1.MsgUnjail",
+			wantKind: "unjail",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewDefaultRegistry()
+			if tt.register != nil {
+				tt.register(r)
+			}
+
+			tev := &structs.TransactionEvent{}
+			err := r.AddSubEvent(tev, &codec_types.Any{TypeUrl: tt.typeURL}, types.ABCIMessageLog{}, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Registry.AddSubEvent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tev.Kind != tt.wantKind {
+				t.Errorf("Registry.AddSubEvent() kind = %v, want %v", tev.Kind, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestRegistry_AuthzExec(t *testing.T) {
+	withdraw, err := codec_types.NewAnyWithValue(&distribution.MsgWithdrawDelegatorReward{
+		DelegatorAddress: "cosmos1granter",
+		ValidatorAddress: "cosmosvaloper1validator",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	delegate, err := codec_types.NewAnyWithValue(&staking.MsgDelegate{
+		DelegatorAddress: "cosmos1granter",
+		ValidatorAddress: "cosmosvaloper1validator",
+		Amount:           types.NewInt64Coin("uatom", 100),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exec := &authz.MsgExec{Grantee: "cosmos1grantee", Msgs: []*codec_types.Any{withdraw, delegate}}
+	execAny, err := codec_types.NewAnyWithValue(exec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lg := types.ABCIMessageLog{Events: []types.StringEvent{
+		{Type: "transfer", Attributes: []types.Attribute{{Key: "recipient", Value: "cosmos1granter"}, {Key: "sender", Value: "cosmos1distribution"}, {Key: "amount", Value: "25uatom"}}},
+	}}
+
+	tev := &structs.TransactionEvent{}
+	if err := NewDefaultRegistry().AddSubEvent(tev, execAny, lg, nil); err != nil {
+		t.Fatalf("Registry.AddSubEvent() error = %v", err)
+	}
+	if tev.Kind != "exec" || len(tev.Sub) != 1 {
+		t.Fatalf("Registry.AddSubEvent() unexpected event %+v", tev)
+	}
+
+	ev := tev.Sub[0]
+	if _, ok := ev.Additional["msgs"]; ok {
+		t.Errorf("raw messages should not be present")
+	}
+	// transfers are counted only in the subevent of the executed message
+	if len(ev.Transfers) != 0 {
+		t.Errorf("unexpected exec transfers %+v", ev.Transfers)
+	}
+	if len(ev.Sub) != 2 {
+		t.Fatalf("expected 2 executed messages, got %d", len(ev.Sub))
+	}
+	for i, want := range []string{"withdraw_delegator_reward", "delegate"} {
+		sub := ev.Sub[i]
+		if sub.Type[0] != want {
+			t.Errorf("Sub[%d] type = %v, want %v", i, sub.Type, want)
+		}
+		if sub.Node["granter"][0].ID != "cosmos1granter" || sub.Node["grantee"][0].ID != "cosmos1grantee" {
+			t.Errorf("Sub[%d] not attributed to granter %+v", i, sub.Node)
+		}
+	}
+	// events of messages of the same granter are attributed to the first one
+	if r := ev.Sub[0].Transfers["reward"]; len(r) != 1 || r[0].Account.ID != "cosmos1granter" {
+		t.Errorf("Sub[0] unexpected transfers %+v", ev.Sub[0].Transfers)
+	}
+	if len(ev.Sub[1].Transfers) != 0 {
+		t.Errorf("Sub[1] shouldn't have transfers of the first message %+v", ev.Sub[1].Transfers)
+	}
+	if g := ev.Additional["granter"]; len(g) != 1 || g[0] != "cosmos1granter" {
+		t.Errorf("unexpected granters %v", g)
+	}
+}
+
+func TestRegistry_AuthzExec_Granters(t *testing.T) {
+	var msgs []*codec_types.Any
+	for _, granter := range []string{"cosmos1granter1", "cosmos1granter2"} {
+		withdraw, err := codec_types.NewAnyWithValue(&distribution.MsgWithdrawDelegatorReward{
+			DelegatorAddress: granter,
+			ValidatorAddress: "cosmosvaloper1validator",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		msgs = append(msgs, withdraw)
+	}
+	execAny, err := codec_types.NewAnyWithValue(&authz.MsgExec{Grantee: "cosmos1grantee", Msgs: msgs})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// events of both messages are merged by type in the log
+	lg := types.ABCIMessageLog{Events: []types.StringEvent{
+		{Type: "coin_received", Attributes: []types.Attribute{
+			{Key: "receiver", Value: "cosmos1granter1"}, {Key: "amount", Value: "25uatom"},
+			{Key: "receiver", Value: "cosmos1granter2"}, {Key: "amount", Value: "40uatom"},
+		}},
+		{Type: "transfer", Attributes: []types.Attribute{
+			{Key: "recipient", Value: "cosmos1granter1"}, {Key: "sender", Value: "cosmos1distribution"}, {Key: "amount", Value: "25uatom"},
+			{Key: "recipient", Value: "cosmos1granter2"}, {Key: "sender", Value: "cosmos1distribution"}, {Key: "amount", Value: "40uatom"},
+			{Key: "recipient", Value: "cosmos1module"}, {Key: "sender", Value: "cosmos1distribution"}, {Key: "amount", Value: "1uatom"},
+		}},
+		{Type: "withdraw_rewards", Attributes: []types.Attribute{
+			{Key: "amount", Value: "25uatom"}, {Key: "validator", Value: "cosmosvaloper1validator"},
+			{Key: "amount", Value: "40uatom"}, {Key: "validator", Value: "cosmosvaloper1validator"},
+		}},
+	}}
+
+	tev := &structs.TransactionEvent{}
+	if err := NewDefaultRegistry().AddSubEvent(tev, execAny, lg, nil); err != nil {
+		t.Fatalf("Registry.AddSubEvent() error = %v", err)
+	}
+	ev := tev.Sub[0]
+	if len(ev.Sub) != 2 {
+		t.Fatalf("expected 2 executed messages, got %d", len(ev.Sub))
+	}
+	for i, want := range []struct{ granter, amount string }{{"cosmos1granter1", "25"}, {"cosmos1granter2", "40"}} {
+		sub := ev.Sub[i]
+		if sub.Node["granter"][0].ID != want.granter {
+			t.Errorf("Sub[%d] granter = %+v, want %v", i, sub.Node["granter"], want.granter)
+		}
+		r := sub.Transfers["reward"]
+		if len(r) != 1 || r[0].Account.ID != want.granter || r[0].Amounts[0].Numeric.String() != want.amount {
+			t.Errorf("Sub[%d] unexpected reward transfers %+v", i, r)
+		}
+	}
+	// only the transfer not attributed to any executed message is left on MsgExec
+	if tr := ev.Transfers["send"]; len(tr) != 1 || tr[0].Account.ID != "cosmos1module" {
+		t.Errorf("unexpected exec transfers %+v", ev.Transfers)
+	}
+}
+
+func TestRegistry_AuthzExecAt(t *testing.T) {
+	vote, err := protov2.Marshal(&govv1.MsgVote{ProposalId: 7, Voter: "cosmos1granter", Option: govv1.VoteOption_VOTE_OPTION_YES})
+	if err != nil {
+		t.Fatal(err)
+	}
+	execAny, err := codec_types.NewAnyWithValue(&authz.MsgExec{Grantee: "cosmos1grantee", Msgs: []*codec_types.Any{{TypeUrl: "/cosmos.gov.v1.MsgVote", Value: vote}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// executed messages are mapped at the height of MsgExec, gov v1 exists since v0.46
+	schedule := util.UpgradeSchedule{{Height: 100, SDKVersion: "v0.45.16"}, {Height: 200, SDKVersion: "v0.46.0"}}
+	r := NewDefaultRegistry()
+	tev := &structs.TransactionEvent{}
+	if err := r.AddSubEventAt(tev, execAny, types.ABCIMessageLog{}, nil, 150, schedule); !errors.Is(err, util.ErrUnknownMessageType) {
+		t.Errorf("Registry.AddSubEventAt() v0.45 error = %v, want %v", err, util.ErrUnknownMessageType)
+	}
+	tev = &structs.TransactionEvent{}
+	if err := r.AddSubEventAt(tev, execAny, types.ABCIMessageLog{}, nil, 250, schedule); err != nil {
+		t.Fatalf("Registry.AddSubEventAt() v0.46 error = %v", err)
+	}
+	if len(tev.Sub) != 1 || len(tev.Sub[0].Sub) != 1 || tev.Sub[0].Sub[0].Type[0] != "vote" {
+		t.Errorf("Registry.AddSubEventAt() unexpected event %+v", tev)
+	}
+}
+
+func TestRegistry_GovV1SubmitProposal(t *testing.T) {
+	content, err := codec_types.NewAnyWithValue(&gov.TextProposal{Title: "title", Description: "description"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, err := protov2.Marshal(&govv1.MsgExecLegacyContent{
+		Content:   &anypb.Any{TypeUrl: content.TypeUrl, Value: content.Value},
+		Authority: "cosmos1gov",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp, err := protov2.Marshal(&govv1.MsgSubmitProposal{
+		Messages: []*anypb.Any{
+			{TypeUrl: "/cosmos.gov.v1.MsgExecLegacyContent", Value: legacy},
+			{TypeUrl: "/cosmos.gov.v1.MsgUpdateParams"},
+		},
+		InitialDeposit: []*basev1beta1.Coin{{Denom: "uatom", Amount: "100"}},
+		Proposer:       "cosmos1proposer",
+		Metadata:       "ipfs://metadata",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tev := &structs.TransactionEvent{}
+	if err := NewDefaultRegistry().AddSubEvent(tev, &codec_types.Any{TypeUrl: "/cosmos.gov.v1.MsgSubmitProposal", Value: sp}, types.ABCIMessageLog{}, nil); err != nil {
+		t.Fatalf("Registry.AddSubEvent() error = %v", err)
+	}
+	if tev.Kind != "submit_proposal" || len(tev.Sub) != 1 {
+		t.Fatalf("Registry.AddSubEvent() unexpected event %+v", tev)
+	}
+
+	ev := tev.Sub[0]
+	if ev.Additional["metadata"][0] != "ipfs://metadata" {
+		t.Errorf("unexpected metadata %v", ev.Additional["metadata"])
+	}
+	if am := ev.Amount["initial_deposit"]; am.Currency != "uatom" || am.Text != "100" {
+		t.Errorf("unexpected initial_deposit %+v", am)
+	}
+	if len(ev.Additional["messages_type_url"]) != 2 {
+		t.Errorf("unexpected messages_type_url %v", ev.Additional["messages_type_url"])
+	}
+	if len(ev.Sub) != 1 {
+		t.Fatalf("expected 1 decoded message, got %d", len(ev.Sub))
+	}
+	if sub := ev.Sub[0]; sub.Type[0] != "exec_legacy_content" || sub.Additional["title"][0] != "title" || sub.Additional["proposal_type"][0] != "Text" {
+		t.Errorf("unexpected legacy content %+v", sub)
+	}
+}
+
+func TestRegistry_GovV1ProposalID(t *testing.T) {
+	vote, err := protov2.Marshal(&govv1.MsgVote{ProposalId: 7, Voter: "cosmos1voter", Option: govv1.VoteOption_VOTE_OPTION_YES})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dep, err := protov2.Marshal(&govv1.MsgDeposit{ProposalId: 7, Depositor: "cosmos1depositor", Amount: []*basev1beta1.Coin{{Denom: "uatom", Amount: "100"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewDefaultRegistry()
+	for typeURL, msg := range map[string][]byte{"/cosmos.gov.v1.MsgVote": vote, "/cosmos.gov.v1.MsgDeposit": dep} {
+		tev := &structs.TransactionEvent{}
+		if err := r.AddSubEvent(tev, &codec_types.Any{TypeUrl: typeURL, Value: msg}, types.ABCIMessageLog{}, nil); err != nil {
+			t.Fatalf("Registry.AddSubEvent(%s) error = %v", typeURL, err)
+		}
+		if len(tev.Sub) != 1 || len(tev.Sub[0].Additional["proposal_id"]) != 1 || tev.Sub[0].Additional["proposal_id"][0] != "7" {
+			t.Errorf("Registry.AddSubEvent(%s) unexpected proposal_id in %+v", typeURL, tev.Sub)
+		}
+	}
+}
+
+func TestRegistry_GovV1SubmitProposal_MalformedMessage(t *testing.T) {
+	content, err := codec_types.NewAnyWithValue(&gov.TextProposal{Title: "title", Description: "description"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, err := protov2.Marshal(&govv1.MsgExecLegacyContent{
+		Content:   &anypb.Any{TypeUrl: content.TypeUrl, Value: content.Value},
+		Authority: "cosmos1gov",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp, err := protov2.Marshal(&govv1.MsgSubmitProposal{
+		Messages: []*anypb.Any{
+			{TypeUrl: "/cosmos.bank.v1beta1.MsgSend", Value: []byte{0xff, 0xff}},
+			{TypeUrl: "/cosmos.gov.v1.MsgExecLegacyContent", Value: legacy},
+		},
+		Proposer: "cosmos1proposer",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tev := &structs.TransactionEvent{}
+	err = NewDefaultRegistry().AddSubEvent(tev, &codec_types.Any{TypeUrl: "/cosmos.gov.v1.MsgSubmitProposal", Value: sp}, types.ABCIMessageLog{}, nil)
+	if !errors.Is(err, util.ErrMalformedMessage) {
+		t.Fatalf("Registry.AddSubEvent() error = %v, want %v", err, util.ErrMalformedMessage)
+	}
+	if len(tev.Sub) != 1 {
+		t.Fatalf("Registry.AddSubEvent() proposal not mapped %+v", tev)
+	}
+	// the malformed message doesn't drop the rest of the proposal
+	if subs := tev.Sub[0].Sub; len(subs) != 1 || subs[0].Type[0] != "exec_legacy_content" {
+		t.Errorf("unexpected proposal messages %+v", subs)
+	}
+}
+
+func TestNewChainRegistry(t *testing.T) {
+	p, err := util.BuiltinChainProfile("osmosis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewChainRegistry(p)
+	for _, typeURL := range []string{"/cosmos.bank.v1beta1.MsgSend", "/osmosis.gamm.v1beta1.MsgJoinPool", "/osmosis.lockup.MsgLockTokens"} {
+		if _, ok := r.Handler(typeURL); !ok {
+			t.Errorf("NewChainRegistry() missing handler for %s", typeURL)
+		}
+	}
+	if _, ok := r.Handler("/tendermint.liquidity.v1beta1.MsgCreatePool"); ok {
+		t.Error("NewChainRegistry() unexpected liquidity handler")
+	}
+
+	ma, err := mapper.NewMapper(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ma.UnbondedAddress != "osmo1tygms3xhhs3yv487phx3dw4a95jn7t7lfqxwe3" || ma.BondedAddress != "osmo1fl48vsnmsdzcv85q5d2q4z5ajdha8yu3aq6l09" {
+		t.Errorf("NewMapper() = %+v", ma)
+	}
+}
+
+func TestRegistry_MappingError(t *testing.T) {
+	tests := []struct {
+		name     string
+		typeURL  string
+		value    []byte
+		wantKind error
+	}{
+		{name: "unknown", typeURL: "/osmosis.gamm.v1beta1.MsgSwapExactAmountIn", wantKind: util.ErrUnknownMessageType},
+		{name: "malformed", typeURL: "/cosmos.bank.v1beta1.MsgSend", value: []byte{0xff, 0xff}, wantKind: util.ErrMalformedMessage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tev := &structs.TransactionEvent{}
+			err := NewDefaultRegistry().AddSubEvent(tev, &codec_types.Any{TypeUrl: tt.typeURL, Value: tt.value}, types.ABCIMessageLog{MsgIndex: 2}, nil)
+			if !errors.Is(err, tt.wantKind) {
+				t.Fatalf("Registry.AddSubEvent() error = %v, want kind %v", err, tt.wantKind)
+			}
+			var me *util.MappingError
+			if !errors.As(err, &me) {
+				t.Fatalf("Registry.AddSubEvent() error %v is not MappingError", err)
+			}
+			if me.Kind != tt.wantKind || me.TypeURL != tt.typeURL || me.MsgIndex != 2 {
+				t.Errorf("Registry.AddSubEvent() unexpected error details %+v", me)
+			}
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/gogo/protobuf/proto"
 	client "github.com/gravity-devs/liquidity/x/liquidity/types"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
 )
 
 // see types https://github.com/Gravity-Devs/liquidity/blob/44220af8ebd5b664768b4098a2159b75ca02df8a/x/liquidity/spec/04_messages.md
@@ -17,7 +19,7 @@ import (
 func TendermintCreatePool(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &client.MsgCreatePool{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a create_pool type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a create_pool type: %w", err)
 	}
 
 	se = shared.SubsetEvent{
@@ -47,7 +49,7 @@ func TendermintCreatePool(msg []byte) (se shared.SubsetEvent, err error) {
 func TendermintDepositWithinBatch(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &client.MsgDepositWithinBatch{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a deposit_within_batch type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a deposit_within_batch type: %w", err)
 	}
 
 	se = shared.SubsetEvent{
@@ -79,7 +81,7 @@ func TendermintDepositWithinBatch(msg []byte) (se shared.SubsetEvent, err error)
 func TendermintWithdrawWithinBatch(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &client.MsgWithdrawWithinBatch{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a withdraw_within_batch type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a withdraw_within_batch type: %w", err)
 	}
 
 	return shared.SubsetEvent{
@@ -108,7 +110,7 @@ func TendermintWithdrawWithinBatch(msg []byte) (se shared.SubsetEvent, err error
 func TendermintSwapWithinBatch(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &client.MsgSwapWithinBatch{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a swap_within_batch type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a swap_within_batch type: %w", err)
 	}
 
 	return shared.SubsetEvent{
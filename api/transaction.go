@@ -0,0 +1,154 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	codec_types "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	auth "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/figment-networks/indexing-engine/structs"
+
+	"github.com/figment-networks/ni-cosmoslib/client/cosmosgrpc"
+	"github.com/figment-networks/ni-cosmoslib/util"
+
+	"github.com/figment-networks/ni-cosmoslib/api/mapper"
+)
+
+// TransactionVersion is the version of structs.Transaction records created by MapTransaction
+const TransactionVersion = "0.0.1"
+
+// IBCSubEventAt maps ibc messages, ie ibcmapper.AddIBCSubEventAt of the ibc-go version used by the chain
+type IBCSubEventAt func(tev *structs.TransactionEvent, m *codec_types.Any, lg types.ABCIMessageLog, height uint64, schedule util.UpgradeSchedule) error
+
+// TransactionMapper maps transactions with all their messages to structs.Transaction
+type TransactionMapper struct {
+	ChainID  string
+	Schedule util.UpgradeSchedule
+
+	// Registries are consulted in order, the first one with the handler of the message maps it
+	Registries []*Registry
+	// IBC maps messages with "/ibc." TypeUrls, ibc messages are unknown when it's nil
+	IBC IBCSubEventAt
+	// EventFallback maps messages of unknown types from their log events (see AddEventSubEvent)
+	EventFallback bool
+	// Mapper is used by the registries and for the transaction fee
+	Mapper *mapper.Mapper
+}
+
+// DefaultTransactionMapper is used by MapTransaction
+var DefaultTransactionMapper = &TransactionMapper{
+	Registries: []*Registry{DefaultRegistry, TendermintRegistry, OsmosisRegistry},
+	Mapper:     prefixMapper("cosmos"),
+}
+
+// prefixMapper returns Mapper of the bech32 prefix with the fee collector module account derived from it,
+// fee transfers are not mapped when the address can't be encoded
+func prefixMapper(prefix string) *mapper.Mapper {
+	feeCollector, _ := mapper.ModuleAddress(prefix, auth.FeeCollectorName)
+	return &mapper.Mapper{Bech32Prefix: prefix, FeeCollectorAddress: feeCollector}
+}
+
+// NewTransactionMapper creates TransactionMapper for the chain profile, ibc can be nil when ibc messages are not mapped
+func NewTransactionMapper(p util.ChainProfile, ibc IBCSubEventAt) (*TransactionMapper, error) {
+	p = p.WithDefaults()
+	ma, err := mapper.NewMapper(p)
+	if err != nil {
+		return nil, err
+	}
+	return &TransactionMapper{
+		ChainID:    p.ChainID,
+		Schedule:   p.Upgrades,
+		Registries: []*Registry{NewChainRegistry(p)},
+		IBC:        ibc,
+		Mapper:     ma,
+	}, nil
+}
+
+// MapTransaction maps the transaction with DefaultTransactionMapper
+func MapTransaction(t *tx.Tx, resp *types.TxResponse, height uint64, blockTime time.Time) (structs.Transaction, error) {
+	return DefaultTransactionMapper.MapTransaction(t, resp, height, blockTime)
+}
+
+// MapTransaction creates structs.Transaction with the fee event and events of all the transaction messages. Every message is mapped
+// even if some of them fail, the returned error is the first mapping error (see util.MappingError).
+// Failed transactions have no logs, their message subevents are marked with MarkFailed and only the fee is transferred.
+func (tm *TransactionMapper) MapTransaction(t *tx.Tx, resp *types.TxResponse, height uint64, blockTime time.Time) (trans structs.Transaction, err error) {
+	trans = structs.Transaction{
+		Hash:      resp.TxHash,
+		Height:    height,
+		ChainID:   tm.ChainID,
+		Time:      blockTime,
+		GasWanted: uint64(resp.GasWanted),
+		GasUsed:   uint64(resp.GasUsed),
+		Memo:      t.GetBody().GetMemo(),
+		Version:   TransactionVersion,
+		RawLog:    []byte(resp.RawLog),
+		HasErrors: resp.Code != 0,
+	}
+	fee := t.GetAuthInfo().GetFee().GetAmount()
+	trans.Fee = mapper.CoinsToAmounts(fee)
+
+	ma := tm.Mapper
+	if ma == nil {
+		ma = defaultMapper
+	}
+	// the fee error is the first one, but the messages are still mapped
+	feeEv, err := ma.AuthInfoToSub(t.GetAuthInfo(), t.GetBody().GetMessages(), types.StringifyEvents(resp.Events))
+	trans.Events = append(trans.Events, structs.TransactionEvent{
+		ID:     "fee",
+		Kind:   "fee",
+		Module: "auth",
+		Type:   []string{"fee"},
+		Sub:    []structs.SubsetEvent{feeEv},
+	})
+
+	var txErr cosmosgrpc.TxLogError
+	if trans.HasErrors {
+		txErr = TxLogError(resp)
+	}
+	for i, m := range t.GetBody().GetMessages() {
+		tev := structs.TransactionEvent{ID: strconv.Itoa(i)}
+		lg := MessageLog(resp, i, height, tm.Schedule)
+		mErr := tm.addSubEvent(&tev, m, lg, height)
+		if tm.EventFallback && errors.Is(mErr, util.ErrUnknownMessageType) {
+			mErr = AddEventSubEvent(&tev, m, lg, tm.Mapper)
+		}
+		if mErr != nil && err == nil {
+			err = mErr
+		}
+		if trans.HasErrors {
+			MarkFailed(&tev, txErr)
+		}
+		trans.Events = append(trans.Events, tev)
+	}
+
+	return trans, err
+}
+
+// addSubEvent routes the message to the ibc mapper or the first registry with its handler
+func (tm *TransactionMapper) addSubEvent(tev *structs.TransactionEvent, m *codec_types.Any, lg types.ABCIMessageLog, height uint64) error {
+	if strings.HasPrefix(m.TypeUrl, "/ibc.") {
+		if tm.IBC == nil {
+			return util.WithMessage(fmt.Errorf("problem with ibc event %s: %w", m.TypeUrl, util.ErrUnknownMessageType), "", "", m.TypeUrl, int(lg.MsgIndex))
+		}
+		return tm.IBC(tev, m, lg, height, tm.Schedule)
+	}
+
+	if len(tm.Registries) == 0 {
+		return util.WithMessage(fmt.Errorf("problem with event %s: %w", m.TypeUrl, util.ErrUnknownMessageType), "", "", m.TypeUrl, int(lg.MsgIndex))
+	}
+	u := tm.Schedule.At(height)
+	r := tm.Registries[0]
+	for _, reg := range tm.Registries {
+		if _, ok := reg.HandlerAt(m.TypeUrl, u); ok {
+			r = reg
+			break
+		}
+	}
+	return r.AddSubEventAt(tev, m, lg, tm.Mapper, height, tm.Schedule)
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	codec_types "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
+
+	"github.com/figment-networks/ni-cosmoslib/api/mapper"
+)
+
+func TestMapTransaction(t *testing.T) {
+	pk := secp256k1.GenPrivKey().PubKey()
+	signer, err := bech32.ConvertAndEncode("cosmos", pk.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkAny, err := codec_types.NewAnyWithValue(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	send, err := codec_types.NewAnyWithValue(&bank.MsgSend{
+		FromAddress: signer,
+		ToAddress:   "cosmos1recipient",
+		Amount:      types.NewCoins(types.NewInt64Coin("uatom", 100)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transaction := &tx.Tx{
+		Body: &tx.TxBody{
+			Messages: []*codec_types.Any{send, {TypeUrl: "/ibc.applications.transfer.v1.MsgTransfer"}},
+			Memo:     "memo",
+		},
+		AuthInfo: &tx.AuthInfo{
+			SignerInfos: []*tx.SignerInfo{{PublicKey: pkAny}},
+			Fee:         &tx.Fee{Amount: types.NewCoins(types.NewInt64Coin("uatom", 5)), GasLimit: 200000},
+		},
+	}
+	resp := &types.TxResponse{
+		TxHash:    "HASH",
+		GasWanted: 200000,
+		GasUsed:   80000,
+		Logs: types.ABCIMessageLogs{{MsgIndex: 0, Events: types.StringEvents{{Type: "transfer", Attributes: []types.Attribute{
+			{Key: "recipient", Value: "cosmos1recipient"}, {Key: "sender", Value: signer}, {Key: "amount", Value: "100uatom"},
+		}}}}},
+	}
+
+	trans, err := MapTransaction(transaction, resp, 100, time.Unix(1000, 0))
+	if !errors.Is(err, util.ErrUnknownMessageType) {
+		t.Errorf("MapTransaction() error = %v, want unknown ibc message", err)
+	}
+	if trans.Hash != "HASH" || trans.Memo != "memo" || trans.GasUsed != 80000 || trans.GasWanted != 200000 || trans.HasErrors {
+		t.Errorf("MapTransaction() unexpected transaction %+v", trans)
+	}
+	if len(trans.Fee) != 1 || trans.Fee[0].Text != "5" || trans.Fee[0].Currency != "uatom" {
+		t.Errorf("MapTransaction() unexpected fee %+v", trans.Fee)
+	}
+	if len(trans.Events) != 3 {
+		t.Fatalf("MapTransaction() expected fee and 2 message events, got %d", len(trans.Events))
+	}
+	if s := trans.Events[0].Sub[0].Node["signer"]; len(s) != 1 || s[0].ID != signer {
+		t.Errorf("MapTransaction() unexpected signers %+v", s)
+	}
+	feeCollector, err := mapper.ModuleAddress("cosmos", "fee_collector")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee := trans.Events[0].Sub[0].Transfers["fee"]; len(fee) != 1 || fee[0].Account.ID != feeCollector {
+		t.Errorf("MapTransaction() unexpected fee transfers %+v", fee)
+	}
+	if ev := trans.Events[1]; ev.Kind != "send" || len(ev.Sub[0].Transfers["send"]) != 1 {
+		t.Errorf("MapTransaction() unexpected send event %+v", ev)
+	}
+	if ev := trans.Events[2]; ev.ID != "1" || len(ev.Sub) != 0 {
+		t.Errorf("MapTransaction() unexpected ibc event %+v", ev)
+	}
+}
+
+func TestMapTransaction_Failed(t *testing.T) {
+	send, err := codec_types.NewAnyWithValue(&bank.MsgSend{
+		FromAddress: "cosmos1sender",
+		ToAddress:   "cosmos1recipient",
+		Amount:      types.NewCoins(types.NewInt64Coin("uatom", 100)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	transaction := &tx.Tx{
+		Body:     &tx.TxBody{Messages: []*codec_types.Any{send}},
+		AuthInfo: &tx.AuthInfo{Fee: &tx.Fee{Amount: types.NewCoins(types.NewInt64Coin("uatom", 5)), Payer: "cosmos1sender"}},
+	}
+	resp := &types.TxResponse{
+		TxHash:    "HASH",
+		Codespace: "sdk",
+		Code:      5,
+		RawLog:    "failed to execute message; message index: 0: 10uatom is smaller than 100uatom: insufficient funds",
+	}
+
+	trans, err := MapTransaction(transaction, resp, 100, time.Unix(1000, 0))
+	if err != nil {
+		t.Fatalf("MapTransaction() error = %v", err)
+	}
+	if !trans.HasErrors || len(trans.Events) != 2 {
+		t.Fatalf("MapTransaction() unexpected transaction %+v", trans)
+	}
+	if fee := trans.Events[0].Sub[0].Transfers["fee"]; len(fee) != 1 {
+		t.Errorf("MapTransaction() fee should be transferred %+v", fee)
+	}
+
+	se := trans.Events[1].Sub[0]
+	if se.Error == nil || se.Error.Message != resp.RawLog || se.Additional["error_code"][0] != "5" || se.Additional["error_codespace"][0] != "sdk" {
+		t.Errorf("MapTransaction() unexpected error %+v %v", se.Error, se.Additional)
+	}
+	if len(se.Sender[0].Amounts) != 0 || len(se.Recipient[0].Amounts) != 0 || len(se.Transfers) != 0 {
+		t.Errorf("MapTransaction() funds of failed transaction moved %+v", se)
+	}
+	if am := se.Amount["attempted"]; am.Text != "100" || am.Currency != "uatom" {
+		t.Errorf("MapTransaction() unexpected attempted amount %+v", am)
+	}
+}
+
+func TestTxLogError(t *testing.T) {
+	txErr := TxLogError(&types.TxResponse{RawLog: `{"codespace":"sdk","code":10,"message":"insufficient account funds"}`})
+	if txErr.Codespace != "sdk" || txErr.Code != 10 || txErr.Message != "insufficient account funds" {
+		t.Errorf("TxLogError() unexpected error %+v", txErr)
+	}
+}
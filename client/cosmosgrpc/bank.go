@@ -0,0 +1,140 @@
+package cosmosgrpc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+const defaultBankPage = 100
+
+// GetBalances fetches all balances of the address at the height. Amounts are in the base denoms (Exp is 0),
+// DenomMetadata.DisplayAmount converts them to the display denoms.
+func (c *Client) GetBalances(ctx context.Context, height uint64, address string) ([]TransactionAmount, error) {
+	return c.GetAllBalancesPaged(ctx, height, address, 0, defaultBankPage)
+}
+
+// GetAllBalancesPaged fetches balances of the address at the height by pages of the size page, up to limit (0 for all).
+// Amounts are in the base denoms like in GetBalances.
+func (c *Client) GetAllBalancesPaged(ctx context.Context, height uint64, address string, limit, page uint64) (balances []TransactionAmount, err error) {
+	pagination := &query.PageRequest{Limit: page}
+	for {
+		if limit > 0 && limit-uint64(len(balances)) < page {
+			pagination.Limit = limit - uint64(len(balances))
+		}
+		ab, err := c.bankClient.AllBalances(metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatUint(height, 10)),
+			&bankTypes.QueryAllBalancesRequest{Address: address, Pagination: pagination})
+		if err != nil {
+			return balances, err
+		}
+		balances = append(balances, coinsToAmounts(ab.Balances)...)
+
+		if limit > 0 && uint64(len(balances)) >= limit {
+			return balances[:limit], nil
+		}
+		if ab.Pagination == nil || ab.Pagination.NextKey == nil {
+			return balances, nil
+		}
+		pagination.Key = ab.Pagination.NextKey
+	}
+}
+
+// GetTotalSupply fetches total supply of all denoms at the height, amounts are in the base denoms
+func (c *Client) GetTotalSupply(ctx context.Context, height uint64) (supply []TransactionAmount, err error) {
+	pagination := &query.PageRequest{Limit: defaultBankPage}
+	for {
+		ts, err := c.bankClient.TotalSupply(metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatUint(height, 10)),
+			&bankTypes.QueryTotalSupplyRequest{Pagination: pagination})
+		if err != nil {
+			return supply, err
+		}
+		supply = append(supply, coinsToAmounts(ts.Supply)...)
+
+		if ts.Pagination == nil || ts.Pagination.NextKey == nil {
+			return supply, nil
+		}
+		pagination.Key = ts.Pagination.NextKey
+	}
+}
+
+// GetDenomMetadata fetches metadata of the base denom at the height
+func (c *Client) GetDenomMetadata(ctx context.Context, height uint64, denom string) (DenomMetadata, error) {
+	dm, err := c.bankClient.DenomMetadata(metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatUint(height, 10)),
+		&bankTypes.QueryDenomMetadataRequest{Denom: denom})
+	if err != nil {
+		return DenomMetadata{}, err
+	}
+
+	md := DenomMetadata{
+		Description: dm.Metadata.Description,
+		Base:        dm.Metadata.Base,
+		Display:     dm.Metadata.Display,
+		Name:        dm.Metadata.Name,
+		Symbol:      dm.Metadata.Symbol,
+	}
+	for _, du := range dm.Metadata.DenomUnits {
+		md.Units = append(md.Units, DenomUnit{Denom: du.Denom, Exponent: du.Exponent, Aliases: du.Aliases})
+	}
+	return md, nil
+}
+
+// DisplayAmount converts amount of the base denom to the display denom, ie 1500000uatom to 1.5atom
+// (numeric 1500000, exp -6). Amounts of other denoms are returned unchanged.
+func (md DenomMetadata) DisplayAmount(am TransactionAmount) TransactionAmount {
+	if am.Currency != md.Base || am.Numeric == nil {
+		return am
+	}
+	for _, u := range md.Units {
+		if u.Denom != md.Display {
+			continue
+		}
+		exp := am.Exp - int32(u.Exponent)
+		return TransactionAmount{
+			Text:     decimalText(am.Numeric.String(), exp),
+			Currency: md.Display,
+			Numeric:  am.Numeric,
+			Exp:      exp,
+		}
+	}
+	return am
+}
+
+func coinsToAmounts(coins types.Coins) []TransactionAmount {
+	amounts := make([]TransactionAmount, 0, len(coins))
+	for _, c := range coins {
+		amounts = append(amounts, TransactionAmount{
+			Text:     c.Amount.String(),
+			Numeric:  c.Amount.BigInt(),
+			Currency: c.Denom,
+		})
+	}
+	return amounts
+}
+
+// decimalText formats numeric * 10 ^ exp without trailing zeros of the fraction, ie "1500000", -6 as "1.5"
+func decimalText(numeric string, exp int32) string {
+	if exp >= 0 {
+		return numeric + strings.Repeat("0", int(exp))
+	}
+	sign := ""
+	if strings.HasPrefix(numeric, "-") {
+		sign, numeric = "-", numeric[1:]
+	}
+	prec := int(-exp)
+	if len(numeric) <= prec {
+		numeric = strings.Repeat("0", prec-len(numeric)+1) + numeric
+	}
+	fraction := strings.TrimRight(numeric[len(numeric)-prec:], "0")
+	if fraction == "" {
+		return sign + numeric[:len(numeric)-prec]
+	}
+	return fmt.Sprintf("%s%s.%s", sign, numeric[:len(numeric)-prec], fraction)
+}
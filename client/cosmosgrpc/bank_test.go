@@ -0,0 +1,133 @@
+package cosmosgrpc
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"go.uber.org/zap"
+)
+
+// balancesConn serves the coins by pages, the key is the index of the next coin
+func balancesConn(coins types.Coins, ignoreLimit bool, limits *[]uint64) *fakeConn {
+	return &fakeConn{invoke: func(_ context.Context, _ string, args, reply interface{}) error {
+		req := args.(*bankTypes.QueryAllBalancesRequest)
+		*limits = append(*limits, req.Pagination.Limit)
+
+		start := 0
+		if req.Pagination.Key != nil {
+			start, _ = strconv.Atoi(string(req.Pagination.Key))
+		}
+		end := start + int(req.Pagination.Limit)
+		if ignoreLimit {
+			end = start + 3
+		}
+		if end > len(coins) {
+			end = len(coins)
+		}
+		resp := reply.(*bankTypes.QueryAllBalancesResponse)
+		resp.Balances = coins[start:end]
+		resp.Pagination = &query.PageResponse{}
+		if end < len(coins) {
+			resp.Pagination.NextKey = []byte(strconv.Itoa(end))
+		}
+		return nil
+	}}
+}
+
+func TestClient_GetAllBalancesPaged(t *testing.T) {
+	coins := types.NewCoins(
+		types.NewInt64Coin("uakt", 1), types.NewInt64Coin("uatom", 2), types.NewInt64Coin("ujuno", 3),
+		types.NewInt64Coin("uosmo", 4), types.NewInt64Coin("uxprt", 5),
+	)
+	tests := []struct {
+		name        string
+		limit, page uint64
+		ignoreLimit bool
+		wantDenoms  int
+		wantLimits  []uint64
+	}{
+		{name: "all", page: 2, wantDenoms: 5, wantLimits: []uint64{2, 2, 2}},
+		{name: "limit_in_page", limit: 3, page: 2, wantDenoms: 3, wantLimits: []uint64{2, 1}},
+		{name: "limit_below_page", limit: 1, page: 100, wantDenoms: 1, wantLimits: []uint64{1}},
+		{name: "limit_above_balances", limit: 10, page: 2, wantDenoms: 5, wantLimits: []uint64{2, 2, 2}},
+		{name: "node_ignores_limit", limit: 4, page: 2, ignoreLimit: true, wantDenoms: 4, wantLimits: []uint64{2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var limits []uint64
+			c := newClient(zap.NewNop(), balancesConn(coins, tt.ignoreLimit, &limits), &ClientConfig{})
+			balances, err := c.GetAllBalancesPaged(context.Background(), 100, "cosmos1account", tt.limit, tt.page)
+			if err != nil {
+				t.Fatalf("GetAllBalancesPaged() error = %v", err)
+			}
+			if len(balances) != tt.wantDenoms {
+				t.Fatalf("GetAllBalancesPaged() = %+v, want %d balances", balances, tt.wantDenoms)
+			}
+			for i, b := range balances {
+				if b.Currency != coins[i].Denom || b.Text != coins[i].Amount.String() || b.Exp != 0 {
+					t.Errorf("GetAllBalancesPaged() balance %d = %+v, want %s", i, b, coins[i])
+				}
+			}
+			if !reflect.DeepEqual(limits, tt.wantLimits) {
+				t.Errorf("GetAllBalancesPaged() page limits = %v, want %v", limits, tt.wantLimits)
+			}
+		})
+	}
+}
+
+func TestDenomMetadata_DisplayAmount(t *testing.T) {
+	md := DenomMetadata{
+		Base:    "uatom",
+		Display: "atom",
+		Units:   []DenomUnit{{Denom: "uatom"}, {Denom: "matom", Exponent: 3}, {Denom: "atom", Exponent: 6}},
+	}
+	tests := []struct {
+		name     string
+		amount   TransactionAmount
+		wantText string
+		wantCurr string
+		wantExp  int32
+	}{
+		{name: "fraction", amount: TransactionAmount{Text: "1500000", Currency: "uatom", Numeric: big.NewInt(1500000)}, wantText: "1.5", wantCurr: "atom", wantExp: -6},
+		{name: "whole", amount: TransactionAmount{Text: "2000000", Currency: "uatom", Numeric: big.NewInt(2000000)}, wantText: "2", wantCurr: "atom", wantExp: -6},
+		{name: "small", amount: TransactionAmount{Text: "25", Currency: "uatom", Numeric: big.NewInt(25)}, wantText: "0.000025", wantCurr: "atom", wantExp: -6},
+		{name: "negative", amount: TransactionAmount{Text: "-1500000", Currency: "uatom", Numeric: big.NewInt(-1500000)}, wantText: "-1.5", wantCurr: "atom", wantExp: -6},
+		{name: "negative_small", amount: TransactionAmount{Text: "-5", Currency: "uatom", Numeric: big.NewInt(-5)}, wantText: "-0.000005", wantCurr: "atom", wantExp: -6},
+		{name: "zero", amount: TransactionAmount{Text: "0", Currency: "uatom", Numeric: big.NewInt(0)}, wantText: "0", wantCurr: "atom", wantExp: -6},
+		{name: "other_denom", amount: TransactionAmount{Text: "15", Currency: "uosmo", Numeric: big.NewInt(15)}, wantText: "15", wantCurr: "uosmo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := md.DisplayAmount(tt.amount)
+			if got.Text != tt.wantText || got.Currency != tt.wantCurr || got.Exp != tt.wantExp || got.Numeric.Cmp(tt.amount.Numeric) != 0 {
+				t.Errorf("DisplayAmount() = %+v, want %s%s (exp %d)", got, tt.wantText, tt.wantCurr, tt.wantExp)
+			}
+		})
+	}
+}
+
+func TestDecimalText(t *testing.T) {
+	tests := []struct {
+		numeric string
+		exp     int32
+		want    string
+	}{
+		{numeric: "1500000", exp: -6, want: "1.5"},
+		{numeric: "1", exp: -6, want: "0.000001"},
+		{numeric: "-120", exp: -2, want: "-1.2"},
+		{numeric: "-100", exp: -2, want: "-1"},
+		{numeric: "15", exp: 2, want: "1500"},
+		{numeric: "15", exp: 0, want: "15"},
+	}
+	for _, tt := range tests {
+		if got := decimalText(tt.numeric, tt.exp); got != tt.want {
+			t.Errorf("decimalText(%s, %d) = %s, want %s", tt.numeric, tt.exp, got, tt.want)
+		}
+	}
+}
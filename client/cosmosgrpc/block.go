@@ -2,9 +2,13 @@ package cosmosgrpc
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
 	"github.com/tendermint/tendermint/proto/tendermint/types"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
 // GetBlock fetches most recent block from chain
@@ -25,3 +29,55 @@ func (c *Client) GetBlock(ctx context.Context, height uint64) (block *types.Bloc
 
 	return bbh.Block, bbh.BlockId, nil
 }
+
+// BlockResultsMethod is the name of GetBlockResults requests in RateLimit weights
+const BlockResultsMethod = "block_results"
+
+// BlockResultsClient fetches block results, ie the CometBFT RPC client created with NewRPCClient
+type BlockResultsClient interface {
+	BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error)
+}
+
+// NewRPCClient creates CometBFT RPC client for the endpoint (ie "http://localhost:26657")
+func NewRPCClient(endpoint string) (BlockResultsClient, error) {
+	return rpchttp.New(endpoint, "/websocket")
+}
+
+// SetBlockResultsClient sets client used by GetBlockResults, block results are not available over gRPC
+func (c *Client) SetBlockResultsClient(cli BlockResultsClient) {
+	c.blockResultsClient = cli
+}
+
+// GetBlockResults fetches results of the block with BeginBlock and EndBlock events, height 0 is the latest block.
+// CometBFT RPC errors are not gRPC statuses, so of the failed calls only the ones timed out are retried.
+func (c *Client) GetBlockResults(ctx context.Context, height uint64) (*coretypes.ResultBlockResults, error) {
+	if c.blockResultsClient == nil {
+		return nil, errors.New("block results client is not set")
+	}
+
+	var h *int64
+	if height > 0 {
+		hi := int64(height)
+		h = &hi
+	}
+
+	var res *coretypes.ResultBlockResults
+	err := c.retry(ctx, func() (err error) {
+		if err = c.rateLimit.Wait(ctx, BlockResultsMethod, h != nil); err != nil {
+			return err
+		}
+		nctx, cancel := callContext(ctx, c.config().TimeoutBlockCall)
+		defer cancel()
+		res, err = c.blockResultsClient.BlockResults(nctx, h)
+		return err
+	})
+	return res, err
+}
+
+// callContext returns the context of a single call limited by the timeout, 0 doesn't limit the call
+func callContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,50 @@
+package cosmosgrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	"go.uber.org/zap"
+)
+
+type blockResultsFunc func(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error)
+
+func (f blockResultsFunc) BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+	return f(ctx, height)
+}
+
+func TestClient_GetBlockResults_Timeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          *ClientConfig
+		wantDeadline bool
+	}{
+		{name: "no_timeout", cfg: &ClientConfig{}},
+		{name: "nil_config"},
+		{name: "timeout", cfg: &ClientConfig{TimeoutBlockCall: time.Minute}, wantDeadline: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient(zap.NewNop(), &fakeConn{}, tt.cfg)
+			c.SetBlockResultsClient(blockResultsFunc(func(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+				if _, ok := ctx.Deadline(); ok != tt.wantDeadline {
+					t.Errorf("BlockResults() deadline set = %v, want %v", ok, tt.wantDeadline)
+				}
+				return &coretypes.ResultBlockResults{Height: *height}, nil
+			}))
+
+			res, err := c.GetBlockResults(context.Background(), 100)
+			if err != nil {
+				t.Fatalf("GetBlockResults() error = %v", err)
+			}
+			if res.Height != 100 {
+				t.Errorf("GetBlockResults() height = %d", res.Height)
+			}
+		})
+	}
+}
@@ -17,6 +17,8 @@ import (
 type ClientConfig struct {
 	TimeoutBlockCall    time.Duration
 	TimeoutSearchTxCall time.Duration
+	// RetryPolicy is applied to every call of the client, DefaultRetryPolicy is used when nil
+	RetryPolicy *RetryPolicy
 }
 
 type ErrorResolve interface {
@@ -38,28 +40,53 @@ type Client struct {
 	// GRPC
 	tmServiceClient    tmservice.ServiceClient
 	txServiceClient    tx.ServiceClient
-	rateLimiter        *rate.Limiter
+	rateLimit          *RateLimit
 	distributionClient distributionTypes.QueryClient
 	stakingClient      stakingTypes.QueryClient
 	bankClient         bankTypes.QueryClient
+
+	// CometBFT RPC
+	blockResultsClient BlockResultsClient
+}
+
+// config returns the config of the client, the zero one when the client was created with nil config
+func (c *Client) config() ClientConfig {
+	if c.cfg == nil {
+		return ClientConfig{}
+	}
+	return *c.cfg
 }
 
 // NewClient returns a new client for a given endpoint
 func NewClient(logger *zap.Logger, cli *grpc.ClientConn, cfg *ClientConfig) *Client {
-	return &Client{
-		logger:             logger,
-		errorResolve:       &NOOPErrorResolve{},
-		tmServiceClient:    tmservice.NewServiceClient(cli),
-		txServiceClient:    tx.NewServiceClient(cli),
-		distributionClient: distributionTypes.NewQueryClient(cli),
-		stakingClient:      stakingTypes.NewQueryClient(cli),
-		bankClient:         bankTypes.NewQueryClient(cli),
-		cfg:                cfg,
+	return newClient(logger, cli, cfg)
+}
+
+func newClient(logger *zap.Logger, cli grpc.ClientConnInterface, cfg *ClientConfig) *Client {
+	c := &Client{
+		logger:       logger,
+		errorResolve: &NOOPErrorResolve{},
+		cfg:          cfg,
 	}
+
+	// every call waits for the rate limit and is retried with the retry policy of the client
+	conn := &limitedConn{cc: cli, c: c}
+	c.tmServiceClient = tmservice.NewServiceClient(conn)
+	c.txServiceClient = tx.NewServiceClient(conn)
+	c.distributionClient = distributionTypes.NewQueryClient(conn)
+	c.stakingClient = stakingTypes.NewQueryClient(conn)
+	c.bankClient = bankTypes.NewQueryClient(conn)
+	return c
 }
 
+// SetRateLimitter sets the limiter used for both latest and archive queries
 func (c *Client) SetRateLimitter(rateLimiter *rate.Limiter) {
-	c.rateLimiter = rateLimiter
+	c.rateLimit = &RateLimit{Latest: rateLimiter}
+}
+
+// SetRateLimit sets the rate limit with per method weights and separate archive budget
+func (c *Client) SetRateLimit(rl *RateLimit) {
+	c.rateLimit = rl
 }
 
 func (c *Client) SetErrorResolverLimitter(errorResolve ErrorResolve) {
@@ -0,0 +1,229 @@
+package cosmosgrpc
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Endpoint is a node with the range of heights it can serve
+type Endpoint struct {
+	Name string
+	Conn grpc.ClientConnInterface
+	// MinHeight is the lowest available height of a pruned node, 0 for an archive node
+	MinHeight uint64
+	// MaxHeight is the highest available height, 0 for a node following the chain
+	MaxHeight uint64
+}
+
+// EndpointHealth is the health of the endpoint tracked by EndpointPool
+type EndpointHealth struct {
+	Name                string
+	Healthy             bool
+	ConsecutiveFailures uint64
+	LastError           string
+	LastFailure         time.Time
+}
+
+type endpointState struct {
+	Endpoint
+	EndpointHealth
+	unhealthyUntil time.Time
+}
+
+// EndpointPool is the connection of the client routing every call to an endpoint with the requested height
+// (x-cosmos-block-height header or WithHeight) and failing over to the next one on Unavailable and pruned
+// height errors. Failing endpoints are used only as the last resort until the cooldown passes.
+type EndpointPool struct {
+	logger    *zap.Logger
+	cooldown  time.Duration
+	endpoints []*endpointState
+	lock      sync.RWMutex
+}
+
+// NewEndpointPool creates pool of the endpoints, failing endpoints are skipped for the cooldown
+func NewEndpointPool(logger *zap.Logger, endpoints []Endpoint, cooldown time.Duration) *EndpointPool {
+	p := &EndpointPool{logger: logger, cooldown: cooldown}
+	for _, e := range endpoints {
+		p.endpoints = append(p.endpoints, &endpointState{Endpoint: e, EndpointHealth: EndpointHealth{Name: e.Name, Healthy: true}})
+	}
+	return p
+}
+
+// NewPoolClient returns a new client using the pool of endpoints
+func NewPoolClient(logger *zap.Logger, pool *EndpointPool, cfg *ClientConfig) *Client {
+	return newClient(logger, pool, cfg)
+}
+
+type heightKey struct{}
+
+type routeKey struct{}
+
+// WithHeight sets the height used to route the call without sending the height header, the call is
+// rate limited as a query of the historical height
+func WithHeight(ctx context.Context, height uint64) context.Context {
+	return context.WithValue(ctx, heightKey{}, height)
+}
+
+// withRoute sets the height used only to route the call, for calls that don't query the state at the
+// height (ie tx search by tx.height), so these are not rate limited as archive queries
+func withRoute(ctx context.Context, height uint64) context.Context {
+	return context.WithValue(ctx, routeKey{}, height)
+}
+
+// requestHeight returns height the call is routed by, 0 for the latest one
+func requestHeight(ctx context.Context) uint64 {
+	if h, ok := ctx.Value(routeKey{}).(uint64); ok {
+		return h
+	}
+	return stateHeight(ctx)
+}
+
+// stateHeight returns height of the state queried by the call, 0 for the latest one
+func stateHeight(ctx context.Context) uint64 {
+	if h, ok := ctx.Value(heightKey{}).(uint64); ok {
+		return h
+	}
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		return 0
+	}
+	for _, h := range md.Get(grpctypes.GRPCBlockHeightHeader) {
+		if height, err := strconv.ParseUint(h, 10, 64); err == nil {
+			return height
+		}
+	}
+	return 0
+}
+
+func (p *EndpointPool) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	height := requestHeight(ctx)
+	candidates := p.candidates(height)
+	if len(candidates) == 0 {
+		return status.Errorf(codes.Unavailable, "no endpoint for height %d", height)
+	}
+
+	var err error
+	for _, e := range candidates {
+		err = e.Conn.Invoke(ctx, method, args, reply, opts...)
+		if err == nil {
+			p.success(e)
+			return nil
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		switch {
+		case isPruned(err):
+			p.logger.Debug("Height not available on endpoint", zap.String("endpoint", e.Endpoint.Name), zap.Uint64("height", height), zap.Error(err))
+		case isUnavailable(err):
+			p.failure(e, err)
+		default:
+			return err
+		}
+	}
+	return err
+}
+
+func (p *EndpointPool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	height := requestHeight(ctx)
+	candidates := p.candidates(height)
+	if len(candidates) == 0 {
+		return nil, status.Errorf(codes.Unavailable, "no endpoint for height %d", height)
+	}
+
+	var err error
+	for _, e := range candidates {
+		var cs grpc.ClientStream
+		if cs, err = e.Conn.NewStream(ctx, desc, method, opts...); err == nil {
+			return cs, nil
+		}
+		if ctx.Err() != nil || !isUnavailable(err) {
+			return nil, err
+		}
+		p.failure(e, err)
+	}
+	return nil, err
+}
+
+// Health returns health of all the endpoints
+func (p *EndpointPool) Health() (health []EndpointHealth) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	for _, e := range p.endpoints {
+		health = append(health, e.EndpointHealth)
+	}
+	return health
+}
+
+// candidates returns endpoints with the height, healthy ones with the narrowest height range
+// (pruned nodes, sparing archive ones) first
+func (p *EndpointPool) candidates(height uint64) (candidates []*endpointState) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	now := time.Now()
+	for _, e := range p.endpoints {
+		if height == 0 && e.MaxHeight != 0 {
+			continue
+		}
+		if height != 0 && (height < e.MinHeight || (e.MaxHeight != 0 && height > e.MaxHeight)) {
+			continue
+		}
+		candidates = append(candidates, e)
+	}
+
+	healthy := func(e *endpointState) bool { return now.After(e.unhealthyUntil) }
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if hi, hj := healthy(candidates[i]), healthy(candidates[j]); hi != hj {
+			return hi
+		}
+		return candidates[i].MinHeight > candidates[j].MinHeight
+	})
+	return candidates
+}
+
+func (p *EndpointPool) success(e *endpointState) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	e.Healthy = true
+	e.ConsecutiveFailures = 0
+	e.unhealthyUntil = time.Time{}
+}
+
+func (p *EndpointPool) failure(e *endpointState, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	e.Healthy = false
+	e.ConsecutiveFailures++
+	e.LastError = err.Error()
+	e.LastFailure = time.Now()
+	e.unhealthyUntil = e.LastFailure.Add(p.cooldown)
+	p.logger.Warn("Endpoint failure", zap.String("endpoint", e.Endpoint.Name), zap.Uint64("consecutive_failures", e.ConsecutiveFailures), zap.Error(err))
+}
+
+func isUnavailable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// isPruned checks if the error is caused by the requested height being pruned on the node
+func isPruned(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "version does not exist") ||
+		strings.Contains(msg, "is not available, lowest height is") ||
+		strings.Contains(msg, "pruned")
+}
@@ -0,0 +1,126 @@
+package cosmosgrpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testPool(cooldown time.Duration, archive, pruned *fakeConn) *EndpointPool {
+	return NewEndpointPool(zap.NewNop(), []Endpoint{
+		{Name: "archive", Conn: archive},
+		{Name: "pruned", Conn: pruned, MinHeight: 1000},
+		{Name: "old", Conn: &fakeConn{}, MaxHeight: 500},
+	}, cooldown)
+}
+
+func TestEndpointPool_candidates(t *testing.T) {
+	tests := []struct {
+		name   string
+		height uint64
+		want   []string
+	}{
+		{name: "latest", height: 0, want: []string{"pruned", "archive"}},
+		{name: "pruned_range", height: 2000, want: []string{"pruned", "archive"}},
+		{name: "old_range", height: 100, want: []string{"archive", "old"}},
+		{name: "archive_only", height: 600, want: []string{"archive"}},
+	}
+	p := testPool(time.Hour, &fakeConn{}, &fakeConn{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range p.candidates(tt.height) {
+				got = append(got, e.Endpoint.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("candidates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEndpointPool_Invoke(t *testing.T) {
+	tests := []struct {
+		name        string
+		prunedErr   error
+		archiveErr  error
+		wantErr     codes.Code
+		wantArchive int
+		wantHealthy bool
+	}{
+		{name: "pruned_serves", wantHealthy: true},
+		{name: "unavailable_failover", prunedErr: status.Error(codes.Unavailable, "connection refused"), wantArchive: 1},
+		{name: "pruned_height_failover", prunedErr: status.Error(codes.InvalidArgument, "version does not exist"), wantArchive: 1, wantHealthy: true},
+		{name: "other_error", prunedErr: status.Error(codes.NotFound, "tx not found"), wantErr: codes.NotFound, wantHealthy: true},
+		{name: "all_unavailable", prunedErr: status.Error(codes.Unavailable, "connection refused"), archiveErr: status.Error(codes.Unavailable, "connection refused"),
+			wantErr: codes.Unavailable, wantArchive: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := &fakeConn{invoke: func(context.Context, string, interface{}, interface{}) error { return tt.archiveErr }}
+			pruned := &fakeConn{invoke: func(context.Context, string, interface{}, interface{}) error { return tt.prunedErr }}
+			p := testPool(time.Hour, archive, pruned)
+
+			err := p.Invoke(heightContext(2000), "/cosmos.tx.v1beta1.Service/GetTx", nil, nil)
+			if status.Code(err) != tt.wantErr {
+				t.Errorf("Invoke() error = %v, want %s", err, tt.wantErr)
+			}
+			if l := len(archive.Calls()); l != tt.wantArchive {
+				t.Errorf("Invoke() archive calls = %d, want %d", l, tt.wantArchive)
+			}
+			if h := p.Health()[1]; h.Name != "pruned" || h.Healthy != tt.wantHealthy || (!tt.wantHealthy && (h.ConsecutiveFailures != 1 || h.LastError == "")) {
+				t.Errorf("Health() = %+v, want healthy %t", h, tt.wantHealthy)
+			}
+		})
+	}
+
+	t.Run("no_endpoint", func(t *testing.T) {
+		p := NewEndpointPool(zap.NewNop(), []Endpoint{{Name: "pruned", Conn: &fakeConn{}, MinHeight: 1000}}, time.Hour)
+		if err := p.Invoke(heightContext(100), "/cosmos.tx.v1beta1.Service/GetTx", nil, nil); status.Code(err) != codes.Unavailable {
+			t.Errorf("Invoke() error = %v, want Unavailable", err)
+		}
+	})
+}
+
+func TestEndpointPool_Cooldown(t *testing.T) {
+	failing := true
+	pruned := &fakeConn{invoke: func(context.Context, string, interface{}, interface{}) error {
+		if failing {
+			return status.Error(codes.Unavailable, "connection refused")
+		}
+		return nil
+	}}
+	archive := &fakeConn{}
+	p := testPool(50*time.Millisecond, archive, pruned)
+
+	if err := p.Invoke(context.Background(), "/cosmos.tx.v1beta1.Service/GetTx", nil, nil); err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+	// failing endpoint is the last resort during the cooldown
+	if c := p.candidates(0); c[0].Endpoint.Name != "archive" || c[1].Endpoint.Name != "pruned" {
+		t.Errorf("candidates() during cooldown = %s, %s", c[0].Endpoint.Name, c[1].Endpoint.Name)
+	}
+	if err := p.Invoke(context.Background(), "/cosmos.tx.v1beta1.Service/GetTx", nil, nil); err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+	if l := len(pruned.Calls()); l != 1 {
+		t.Errorf("failing endpoint called %d times during the cooldown", l)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	failing = false
+	if err := p.Invoke(context.Background(), "/cosmos.tx.v1beta1.Service/GetTx", nil, nil); err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+	if l := len(pruned.Calls()); l != 2 {
+		t.Errorf("endpoint not used after the cooldown, %d calls", l)
+	}
+	if h := p.Health()[1]; !h.Healthy || h.ConsecutiveFailures != 0 {
+		t.Errorf("Health() after success = %+v", h)
+	}
+}
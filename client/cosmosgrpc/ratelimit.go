@@ -0,0 +1,82 @@
+package cosmosgrpc
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+)
+
+// RateLimit limits requests of the client. Queries of historical heights (with the height header) are usually
+// served by archive nodes and have a separate budget.
+type RateLimit struct {
+	// Latest limits queries of the latest state, nil for no limit
+	Latest *rate.Limiter
+	// Archive limits queries of historical heights, Latest is used when nil
+	Archive *rate.Limiter
+	// Weights are numbers of tokens taken by the full method name (ie "/cosmos.tx.v1beta1.Service/GetTxsEvent"),
+	// other methods take 1 token
+	Weights map[string]int
+}
+
+// Wait blocks until the request of the method is allowed or the context is done
+func (rl *RateLimit) Wait(ctx context.Context, method string, archive bool) error {
+	if rl == nil {
+		return nil
+	}
+	l := rl.Latest
+	if archive && rl.Archive != nil {
+		l = rl.Archive
+	}
+	if l == nil {
+		return nil
+	}
+
+	weight := 1
+	if w, ok := rl.Weights[method]; ok {
+		weight = w
+	}
+	return l.WaitN(ctx, weight)
+}
+
+// UnaryClientInterceptor returns interceptor limiting unary calls, it can be used directly when dialing the connection
+func (rl *RateLimit) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := rl.Wait(ctx, method, isArchive(ctx)); err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// isArchive checks if the outgoing request queries a historical height (height header or WithHeight)
+func isArchive(ctx context.Context) bool {
+	return stateHeight(ctx) != 0
+}
+
+// limitedConn applies the rate limit and the retry policy of the client to every call of the connection,
+// every attempt waits for the rate limit
+type limitedConn struct {
+	cc grpc.ClientConnInterface
+	c  *Client
+}
+
+func (lc *limitedConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	return lc.c.retry(ctx, func() error {
+		if err := lc.c.rateLimit.Wait(ctx, method, isArchive(ctx)); err != nil {
+			return err
+		}
+		return lc.cc.Invoke(ctx, method, args, reply, opts...)
+	})
+}
+
+func (lc *limitedConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (cs grpc.ClientStream, err error) {
+	err = lc.c.retry(ctx, func() (err error) {
+		if err = lc.c.rateLimit.Wait(ctx, method, isArchive(ctx)); err != nil {
+			return err
+		}
+		cs, err = lc.cc.NewStream(ctx, desc, method, opts...)
+		return err
+	})
+	return cs, err
+}
@@ -0,0 +1,123 @@
+package cosmosgrpc
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeConn records calls and answers them with invoke
+type fakeConn struct {
+	invoke func(ctx context.Context, method string, args, reply interface{}) error
+
+	lock  sync.Mutex
+	calls []fakeCall
+}
+
+type fakeCall struct {
+	method string
+	height uint64
+}
+
+func (fc *fakeConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	fc.lock.Lock()
+	fc.calls = append(fc.calls, fakeCall{method: method, height: requestHeight(ctx)})
+	fc.lock.Unlock()
+	if fc.invoke == nil {
+		return nil
+	}
+	return fc.invoke(ctx, method, args, reply)
+}
+
+func (fc *fakeConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Error(codes.Unimplemented, "streams are not supported by fakeConn")
+}
+
+func (fc *fakeConn) Calls() []fakeCall {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+	return append([]fakeCall(nil), fc.calls...)
+}
+
+func heightContext(height uint64) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), grpctypes.GRPCBlockHeightHeader, strconv.FormatUint(height, 10))
+}
+
+func TestRateLimit_Wait(t *testing.T) {
+	const method = "/cosmos.tx.v1beta1.Service/GetTxsEvent"
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		archive     bool
+		method      string
+		wantLatest  int
+		wantArchive int
+	}{
+		{name: "latest", ctx: context.Background(), method: "/cosmos.bank.v1beta1.Query/AllBalances", wantLatest: 1},
+		{name: "latest_weight", ctx: context.Background(), method: method, wantLatest: 5},
+		{name: "archive_header", ctx: heightContext(100), method: method, wantArchive: 5},
+		{name: "archive_with_height", ctx: WithHeight(context.Background(), 100), method: method, wantArchive: 5},
+		{name: "latest_zero_height", ctx: heightContext(0), method: method, wantLatest: 5},
+		{name: "latest_route_height", ctx: withRoute(context.Background(), 100), method: method, wantLatest: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// limiters don't refill during the test, so only the burst is available
+			rl := &RateLimit{
+				Latest:  rate.NewLimiter(rate.Every(time.Hour), 10),
+				Archive: rate.NewLimiter(rate.Every(time.Hour), 10),
+				Weights: map[string]int{method: 5},
+			}
+			c := newClient(zap.NewNop(), &fakeConn{}, &ClientConfig{})
+			c.SetRateLimit(rl)
+
+			if err := c.rateLimit.Wait(tt.ctx, tt.method, isArchive(tt.ctx)); err != nil {
+				t.Fatalf("Wait() error = %v", err)
+			}
+			if !rl.Latest.AllowN(time.Now(), 10-tt.wantLatest) || rl.Latest.Allow() {
+				t.Errorf("Wait() expected %d latest tokens taken", tt.wantLatest)
+			}
+			if !rl.Archive.AllowN(time.Now(), 10-tt.wantArchive) || rl.Archive.Allow() {
+				t.Errorf("Wait() expected %d archive tokens taken", tt.wantArchive)
+			}
+		})
+	}
+}
+
+func TestRateLimit_Conn(t *testing.T) {
+	fc := &fakeConn{}
+	c := newClient(zap.NewNop(), fc, &ClientConfig{})
+	// no archive limiter, archive queries take tokens of the latest one
+	latest := rate.NewLimiter(rate.Every(time.Hour), 2)
+	c.SetRateLimit(&RateLimit{Latest: latest})
+
+	if _, err := c.GetBalances(context.Background(), 100, "cosmos1account"); err != nil {
+		t.Fatalf("GetBalances() error = %v", err)
+	}
+	if calls := fc.Calls(); len(calls) != 1 || calls[0].method != "/cosmos.bank.v1beta1.Query/AllBalances" || calls[0].height != 100 {
+		t.Errorf("unexpected calls %+v", calls)
+	}
+	if !latest.Allow() || latest.Allow() {
+		t.Errorf("expected one token taken")
+	}
+
+	// the call waits for the limiter until the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetBalances(ctx, 100, "cosmos1account"); err == nil {
+		t.Error("GetBalances() expected rate limit error")
+	}
+	if calls := fc.Calls(); len(calls) != 1 {
+		t.Errorf("limited call shouldn't reach the connection %+v", calls)
+	}
+}
@@ -0,0 +1,95 @@
+package cosmosgrpc
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy is the policy of retrying failed calls of the client
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first one, 1 disables retries
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, multiplied by Multiplier after each one up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter is the random fraction (0-1) of the backoff added or subtracted from it
+	Jitter float64
+	// RetryableCodes are the gRPC codes of errors to retry
+	RetryableCodes []codes.Code
+}
+
+// DefaultRetryPolicy is used when the ClientConfig has no RetryPolicy
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableCodes: []codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted},
+	}
+}
+
+// Do calls fn until it succeeds, returns not retryable error or the attempts run out.
+// It stops waiting for the next attempt when the context is done.
+func (rp *RetryPolicy) Do(ctx context.Context, fn func() error) (err error) {
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if attempt >= rp.MaxAttempts || ctx.Err() != nil || !rp.Retryable(err) {
+			return err
+		}
+
+		t := time.NewTimer(rp.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+	}
+}
+
+// Retryable checks if the error has one of the retryable codes
+func (rp *RetryPolicy) Retryable(err error) bool {
+	code := status.Code(err)
+	if errors.Is(err, context.DeadlineExceeded) {
+		code = codes.DeadlineExceeded
+	}
+	for _, c := range rp.RetryableCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff returns the wait after the failed attempt (starting from 1)
+func (rp *RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := float64(rp.InitialBackoff) * math.Pow(rp.Multiplier, float64(attempt-1))
+	if rp.MaxBackoff > 0 && backoff > float64(rp.MaxBackoff) {
+		backoff = float64(rp.MaxBackoff)
+	}
+	backoff *= 1 + rp.Jitter*(2*rand.Float64()-1)
+	if backoff < 0 {
+		return 0
+	}
+	return time.Duration(backoff)
+}
+
+// retry calls fn with the retry policy of the client
+func (c *Client) retry(ctx context.Context, fn func() error) error {
+	rp := c.config().RetryPolicy
+	if rp == nil {
+		rp = DefaultRetryPolicy()
+	}
+	return rp.Do(ctx, fn)
+}
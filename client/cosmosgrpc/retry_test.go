@@ -0,0 +1,136 @@
+package cosmosgrpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     2,
+		RetryableCodes: []codes.Code{codes.Unavailable, codes.DeadlineExceeded},
+	}
+}
+
+func TestRetryPolicy_Do(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	notFound := status.Error(codes.NotFound, "tx not found")
+	tests := []struct {
+		name         string
+		errs         []error
+		wantErr      error
+		wantAttempts int
+	}{
+		{name: "success", errs: []error{nil}, wantAttempts: 1},
+		{name: "retried_success", errs: []error{unavailable, unavailable, nil}, wantAttempts: 3},
+		{name: "max_attempts", errs: []error{unavailable, unavailable, unavailable, nil}, wantErr: unavailable, wantAttempts: 3},
+		{name: "not_retryable", errs: []error{notFound, nil}, wantErr: notFound, wantAttempts: 1},
+		{name: "context_deadline", errs: []error{context.DeadlineExceeded, nil}, wantAttempts: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+			err := testRetryPolicy().Do(context.Background(), func() error {
+				attempts++
+				return tt.errs[attempts-1]
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("Do() attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Do_Cancel(t *testing.T) {
+	rp := testRetryPolicy()
+	rp.InitialBackoff, rp.MaxBackoff = time.Hour, time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var attempts int
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	err := rp.Do(ctx, func() error {
+		attempts++
+		return status.Error(codes.Unavailable, "connection refused")
+	})
+	if status.Code(err) != codes.Unavailable || attempts != 1 {
+		t.Errorf("Do() error = %v after %d attempts, want the last error after 1 attempt", err, attempts)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("Do() waited %s for the backoff after cancel", d)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	rp := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 100 * time.Millisecond},
+		{attempt: 2, want: 200 * time.Millisecond},
+		{attempt: 4, want: 800 * time.Millisecond},
+		{attempt: 5, want: time.Second},
+		{attempt: 20, want: time.Second},
+	}
+	for _, tt := range tests {
+		if got := rp.Backoff(tt.attempt); got != tt.want {
+			t.Errorf("Backoff(%d) = %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+
+	// jitter is applied to the capped backoff
+	rp.Jitter = 0.2
+	for i := 0; i < 100; i++ {
+		if got := rp.Backoff(10); got < 800*time.Millisecond || got > 1200*time.Millisecond {
+			t.Fatalf("Backoff(10) = %s, want 0.8s-1.2s", got)
+		}
+	}
+}
+
+func TestClient_retry(t *testing.T) {
+	var attempts int
+	fc := &fakeConn{invoke: func(context.Context, string, interface{}, interface{}) error {
+		if attempts++; attempts < 3 {
+			return status.Error(codes.Unavailable, "connection refused")
+		}
+		return nil
+	}}
+	c := newClient(zap.NewNop(), fc, &ClientConfig{RetryPolicy: testRetryPolicy()})
+	if _, err := c.GetBalances(context.Background(), 100, "cosmos1account"); err != nil {
+		t.Fatalf("GetBalances() error = %v", err)
+	}
+	if l := len(fc.Calls()); l != 3 {
+		t.Errorf("GetBalances() calls = %d, want 3", l)
+	}
+}
+
+func TestClient_retry_NilConfig(t *testing.T) {
+	notFound := status.Error(codes.NotFound, "account not found")
+	fc := &fakeConn{invoke: func(context.Context, string, interface{}, interface{}) error {
+		return notFound
+	}}
+	// the default retry policy is used, not retryable errors are returned after the first call
+	c := newClient(zap.NewNop(), fc, nil)
+	if _, err := c.GetBalances(context.Background(), 100, "cosmos1account"); !errors.Is(err, notFound) {
+		t.Fatalf("GetBalances() error = %v, want %v", err, notFound)
+	}
+	if l := len(fc.Calls()); l != 1 {
+		t.Errorf("GetBalances() calls = %d, want 1", l)
+	}
+}
@@ -77,3 +77,22 @@ type TransactionAmount struct {
 	// Exponential part of amount obviously 0 by default
 	Exp int32 `json:"exp,omitempty"`
 }
+
+// DenomMetadata is the bank module metadata of the denom
+type DenomMetadata struct {
+	Description string
+	// Base is the denom of the amounts on chain (ie uatom)
+	Base string
+	// Display is the denom suggested to display (ie atom)
+	Display string
+	Name    string
+	Symbol  string
+	Units   []DenomUnit
+}
+
+// DenomUnit is the unit of the denom, 1 unit = 10 ^ Exponent of the base denom
+type DenomUnit struct {
+	Denom    string
+	Exponent uint32
+	Aliases  []string
+}
@@ -26,48 +26,113 @@ type TxLogError struct {
 }
 
 func (c *Client) GetRawTxs(ctx context.Context, height, perPage uint64) (txs []*tx.Tx, txResponses []*types.TxResponse, err error) {
-	pag := &query.PageRequest{
-		CountTotal: true,
-		Limit:      perPage,
+	// the tx service doesn't use the height header, the height is only used to route the calls of EndpointPool
+	it := c.SearchTxs(withRoute(ctx, height), []string{"tx.height=" + strconv.FormatUint(height, 10)}, tx.OrderBy_ORDER_BY_UNSPECIFIED, perPage)
+	for it.Next() {
+		pageTxs, pageResponses := it.Page()
+		txs = append(txs, pageTxs...)
+		txResponses = append(txResponses, pageResponses...)
 	}
+	if err := it.Err(); err != nil {
+		return nil, nil, err
+	}
+	return txs, txResponses, nil
+}
 
-	var skipped uint64
-	var page = uint64(1)
-	for {
-		pag.Offset = (perPage * page) - perPage
-		now := time.Now()
+// GetTx fetches the transaction by hash
+func (c *Client) GetTx(ctx context.Context, hash string) (*tx.Tx, *types.TxResponse, error) {
+	nctx, cancel := callContext(ctx, c.config().TimeoutSearchTxCall)
+	defer cancel()
+	res, err := c.txServiceClient.GetTx(nctx, &tx.GetTxRequest{Hash: hash}, grpc.WaitForReady(true))
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.Tx, res.TxResponse, nil
+}
 
-		nctx, cancel := context.WithTimeout(ctx, c.cfg.TimeoutSearchTxCall)
-		grpcRes, err := c.txServiceClient.GetTxsEvent(nctx, &tx.GetTxsEventRequest{
-			Events:     []string{"tx.height=" + strconv.FormatUint(height, 10)},
-			Pagination: pag,
-		}, grpc.WaitForReady(true))
-		cancel()
+// TxIterator iterates over pages of transactions matching the events, created by SearchTxs
+type TxIterator struct {
+	c      *Client
+	ctx    context.Context
+	events []string
+	order  tx.OrderBy
+	pag    *query.PageRequest
+
+	page    uint64
+	fetched uint64
+	skipped uint64
+	done    bool
+
+	txs         []*tx.Tx
+	txResponses []*types.TxResponse
+	err         error
+}
 
-		c.logger.Debug("Request Time (GetTxsEvent)", zap.Duration("duration", time.Now().Sub(now)))
-		if err != nil {
-			ngrpcRes, nskipped, err := c.skipIfUnresolvable(ctx, height, pag, uint64(len(txs)), err)
-			if err != nil {
-				return nil, nil, err
-			}
-			grpcRes = ngrpcRes
-			skipped = skipped + nskipped
-		}
+// SearchTxs returns iterator over pages of the size pageSize of transactions matching all the events
+// (ie "message.sender=cosmos1...", "transfer.recipient=cosmos1..."). Transactions of unresolvable types
+// are skipped when the ErrorResolve of the client checks the error.
+func (c *Client) SearchTxs(ctx context.Context, events []string, order tx.OrderBy, pageSize uint64) *TxIterator {
+	return &TxIterator{
+		c:      c,
+		ctx:    ctx,
+		events: events,
+		order:  order,
+		pag: &query.PageRequest{
+			CountTotal: true,
+			Limit:      pageSize,
+		},
+		page: 1,
+	}
+}
 
-		txs = append(txs, grpcRes.Txs...)
-		txResponses = append(txResponses, grpcRes.TxResponses...)
+// Next fetches the next page, it returns false when there are no more transactions or on error
+func (it *TxIterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	it.pag.Offset = (it.pag.Limit * it.page) - it.pag.Limit
+	now := time.Now()
+
+	nctx, cancel := callContext(it.ctx, it.c.config().TimeoutSearchTxCall)
+	grpcRes, err := it.c.txServiceClient.GetTxsEvent(nctx, &tx.GetTxsEventRequest{
+		Events:     it.events,
+		Pagination: it.pag,
+		OrderBy:    it.order,
+	}, grpc.WaitForReady(true))
+	cancel()
 
-		if grpcRes.Pagination.GetTotal() <= uint64(len(txs))+skipped {
-			break
+	it.c.logger.Debug("Request Time (GetTxsEvent)", zap.Duration("duration", time.Now().Sub(now)))
+	if err != nil {
+		ngrpcRes, nskipped, err := it.c.skipIfUnresolvable(it.ctx, it.events, it.order, it.pag, it.fetched, err)
+		if err != nil {
+			it.err = err
+			return false
 		}
-		page++
+		grpcRes = ngrpcRes
+		it.skipped = it.skipped + nskipped
 	}
 
-	return txs, txResponses, nil
+	it.txs, it.txResponses = grpcRes.Txs, grpcRes.TxResponses
+	it.fetched += uint64(len(grpcRes.Txs))
+	if grpcRes.Pagination.GetTotal() <= it.fetched+it.skipped || len(grpcRes.Txs) == 0 {
+		it.done = true
+	}
+	it.page++
+	return len(grpcRes.Txs) > 0 || !it.done
+}
+
+// Page returns transactions of the page fetched by Next
+func (it *TxIterator) Page() ([]*tx.Tx, []*types.TxResponse) {
+	return it.txs, it.txResponses
+}
+
+// Err returns the error stopping the iteration
+func (it *TxIterator) Err() error {
+	return it.err
 }
 
 // skipIfUnresolvable fetch the tx one at a time skipping any unresolvable types.
-func (c *Client) skipIfUnresolvable(ctx context.Context, height uint64, pag *query.PageRequest, currentTxCnt uint64, err error) (grpcRes *tx.GetTxsEventResponse, nskipped uint64, errOut error) {
+func (c *Client) skipIfUnresolvable(ctx context.Context, events []string, order tx.OrderBy, pag *query.PageRequest, currentTxCnt uint64, err error) (grpcRes *tx.GetTxsEventResponse, nskipped uint64, errOut error) {
 
 	if !c.errorResolve.Check(err) {
 		return nil, 0, err
@@ -75,7 +140,7 @@ func (c *Client) skipIfUnresolvable(ctx context.Context, height uint64, pag *que
 
 	grpcRes = &tx.GetTxsEventResponse{}
 
-	c.logger.Error("Skipping unresolvable height", zap.Error(err), zap.Uint64("height", height))
+	c.logger.Error("Skipping unresolvable transactions", zap.Error(err), zap.Strings("events", events))
 	grpcRes.Txs = make([]*tx.Tx, 0, pag.Limit)
 	grpcRes.TxResponses = make([]*types.TxResponse, 0, pag.Limit)
 
@@ -87,7 +152,7 @@ func (c *Client) skipIfUnresolvable(ctx context.Context, height uint64, pag *que
 
 	var offset uint64
 	for offset < pag.Limit {
-		ngrpcRes, err := c.getOneTransaction(ctx, height, pag.Offset+offset)
+		ngrpcRes, err := c.getOneTransaction(ctx, events, order, pag.Offset+offset)
 
 		if err != nil {
 			// the tx total count isn't known at least 1 tx was fetched (and was skipped because it was unparseable)
@@ -99,7 +164,7 @@ func (c *Client) skipIfUnresolvable(ctx context.Context, height uint64, pag *que
 				transactionTotal = offset
 				break
 			}
-			// if the tx at this height is an un-parseable message type, skip.
+			// if the tx is an un-parseable message type, skip.
 			if c.errorResolve.Check(err) {
 				offset++
 				skippedTxCount++
@@ -129,15 +194,16 @@ func (c *Client) skipIfUnresolvable(ctx context.Context, height uint64, pag *que
 	return grpcRes, skippedTxCount, nil
 }
 
-func (c *Client) getOneTransaction(ctx context.Context, height, offset uint64) (*tx.GetTxsEventResponse, error) {
-	nctx, cancel := context.WithTimeout(ctx, c.cfg.TimeoutSearchTxCall)
+func (c *Client) getOneTransaction(ctx context.Context, events []string, order tx.OrderBy, offset uint64) (*tx.GetTxsEventResponse, error) {
+	nctx, cancel := callContext(ctx, c.config().TimeoutSearchTxCall)
 	ngrpcRes, err := c.txServiceClient.GetTxsEvent(nctx, &tx.GetTxsEventRequest{
-		Events: []string{"tx.height=" + strconv.FormatUint(height, 10)},
+		Events: events,
 		Pagination: &query.PageRequest{
 			CountTotal: true,
 			Offset:     offset,
 			Limit:      1,
 		},
+		OrderBy: order,
 	}, grpc.WaitForReady(true))
 	cancel()
 	return ngrpcRes, err
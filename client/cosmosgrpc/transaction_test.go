@@ -0,0 +1,117 @@
+package cosmosgrpc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+type unresolvableTypes struct{}
+
+func (unresolvableTypes) Check(err error) bool {
+	return strings.Contains(err.Error(), "unable to resolve type URL")
+}
+
+// txsConn serves total transactions with the memo of their index, pages with the bad ones fail to decode
+func txsConn(total int, bad map[int]bool) *fakeConn {
+	return &fakeConn{invoke: func(_ context.Context, _ string, args, reply interface{}) error {
+		pag := args.(*tx.GetTxsEventRequest).Pagination
+		start := int(pag.Offset)
+		if total > 0 && start >= total {
+			return fmt.Errorf("page should be within [1, %d] range, given %d", (total+int(pag.Limit)-1)/int(pag.Limit), start/int(pag.Limit)+1)
+		}
+		end := start + int(pag.Limit)
+		if end > total {
+			end = total
+		}
+
+		resp := reply.(*tx.GetTxsEventResponse)
+		for i := start; i < end; i++ {
+			if bad[i] {
+				return fmt.Errorf("unable to resolve type URL /custom.v1.MsgUnknown: tx parse error")
+			}
+			resp.Txs = append(resp.Txs, &tx.Tx{Body: &tx.TxBody{Memo: strconv.Itoa(i)}})
+			resp.TxResponses = append(resp.TxResponses, &types.TxResponse{TxHash: strconv.Itoa(i)})
+		}
+		resp.Pagination = &query.PageResponse{Total: uint64(total)}
+		return nil
+	}}
+}
+
+func TestClient_SearchTxs(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int
+		bad      map[int]bool
+		pageSize uint64
+		resolve  ErrorResolve
+		want     []string
+		wantErr  bool
+	}{
+		{name: "pages", total: 5, pageSize: 2, want: []string{"0", "1", "2", "3", "4"}},
+		{name: "empty", total: 0, pageSize: 2},
+		{name: "skip_unresolvable", total: 5, bad: map[int]bool{1: true}, pageSize: 2, resolve: unresolvableTypes{}, want: []string{"0", "2", "3", "4"}},
+		{name: "skip_unresolvable_last", total: 5, bad: map[int]bool{3: true, 4: true}, pageSize: 3, resolve: unresolvableTypes{}, want: []string{"0", "1", "2"}},
+		{name: "skip_unresolvable_all", total: 2, bad: map[int]bool{0: true, 1: true}, pageSize: 2, resolve: unresolvableTypes{}},
+		{name: "unresolvable_error", total: 5, bad: map[int]bool{1: true}, pageSize: 2, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient(zap.NewNop(), txsConn(tt.total, tt.bad), &ClientConfig{TimeoutSearchTxCall: time.Second})
+			if tt.resolve != nil {
+				c.SetErrorResolverLimitter(tt.resolve)
+			}
+
+			var got []string
+			it := c.SearchTxs(context.Background(), []string{"message.sender=cosmos1sender"}, tx.OrderBy_ORDER_BY_ASC, tt.pageSize)
+			for it.Next() {
+				txs, responses := it.Page()
+				if len(txs) != len(responses) {
+					t.Fatalf("Page() has %d transactions and %d responses", len(txs), len(responses))
+				}
+				for i, tr := range txs {
+					if responses[i].TxHash != tr.Body.Memo {
+						t.Errorf("Page() response %s of transaction %s", responses[i].TxHash, tr.Body.Memo)
+					}
+					got = append(got, tr.Body.Memo)
+				}
+			}
+			if (it.Err() != nil) != tt.wantErr {
+				t.Fatalf("Err() = %v, want error %t", it.Err(), tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchTxs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetRawTxs(t *testing.T) {
+	fc := txsConn(3, nil)
+	c := newClient(zap.NewNop(), fc, &ClientConfig{TimeoutSearchTxCall: time.Second})
+	// tx search doesn't query the state at the height, the archive limiter without burst fails the archive calls
+	c.SetRateLimit(&RateLimit{Latest: rate.NewLimiter(rate.Inf, 0), Archive: rate.NewLimiter(rate.Every(time.Hour), 0)})
+	txs, responses, err := c.GetRawTxs(context.Background(), 100, 2)
+	if err != nil {
+		t.Fatalf("GetRawTxs() error = %v", err)
+	}
+	if len(txs) != 3 || len(responses) != 3 {
+		t.Errorf("GetRawTxs() = %d transactions, %d responses, want 3", len(txs), len(responses))
+	}
+	// the height routes the calls without the height header
+	for _, call := range fc.Calls() {
+		if call.height != 100 {
+			t.Errorf("GetRawTxs() call %+v without the height", call)
+		}
+	}
+}
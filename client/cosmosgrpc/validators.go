@@ -3,8 +3,6 @@ package cosmosgrpc
 import (
 	"context"
 	"strconv"
-	"strings"
-	"time"
 
 	"google.golang.org/grpc/metadata"
 
@@ -16,15 +14,11 @@ import (
 )
 
 const (
-	errorThreshold = 5
-	CosmosDecExp   = -1 * types.Precision
+	CosmosDecExp = -1 * types.Precision
 )
 
 func (c *Client) GetHeightValidators(ctx context.Context, height, limit, page uint64) (vals []Validator, err error) {
-	var (
-		consecutiveErrors uint64
-		total             uint64
-	)
+	var total uint64
 	pagination := &query.PageRequest{Limit: page}
 
 	for {
@@ -35,15 +29,9 @@ func (c *Client) GetHeightValidators(ctx context.Context, height, limit, page ui
 			})
 
 		if err != nil {
-			consecutiveErrors++
-			if consecutiveErrors < errorThreshold {
-				<-time.After(1 * time.Second)
-				continue
-			}
 			return vals, err
 		}
 		total += uint64(len(vs.Validators))
-		consecutiveErrors = 0
 		for _, val := range vs.Validators {
 			or, err := c.distributionClient.ValidatorOutstandingRewards(metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatUint(height, 10)),
 				&distributionTypes.QueryValidatorOutstandingRewardsRequest{ValidatorAddress: val.OperatorAddress})
@@ -99,27 +87,14 @@ func (c *Client) GetHeightValidators(ctx context.Context, height, limit, page ui
 }
 
 func (c *Client) GetDelegators(ctx context.Context, height uint64, operatorAddress string, limit, page uint64) (vals []DelegationResponse, err error) {
-	var (
-		consecutiveErrors uint64
-		total             uint64
-	)
+	var total uint64
 	pagination := &query.PageRequest{Limit: page}
 	for {
 		vd, err := c.stakingClient.ValidatorDelegations(metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatUint(height, 10)),
 			&stakingTypes.QueryValidatorDelegationsRequest{ValidatorAddr: operatorAddress, Pagination: pagination})
 		if err != nil {
-			// this is a legit error, no need to retry.
-			if strings.Contains(err.Error(), "validator does not exist") {
-				return vals, err
-			}
-			consecutiveErrors++
-			if consecutiveErrors < errorThreshold {
-				<-time.After(1 * time.Second)
-				continue
-			}
 			return vals, err
 		}
-		consecutiveErrors = 0
 		total += uint64(len(vd.DelegationResponses))
 		for _, dr := range vd.DelegationResponses {
 			vals = append(vals,
@@ -148,23 +123,14 @@ func (c *Client) GetDelegators(ctx context.Context, height uint64, operatorAddre
 }
 
 func (c *Client) GetDelegatorDelegations(ctx context.Context, height uint64, delegatorAddress string, limit, page uint64) (vals []DelegationResponse, err error) {
-	var (
-		consecutiveErrors uint64
-		total             uint64
-	)
+	var total uint64
 	pagination := &query.PageRequest{Limit: page}
 	for {
 		vd, err := c.stakingClient.DelegatorDelegations(metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatUint(height, 10)),
 			&stakingTypes.QueryDelegatorDelegationsRequest{DelegatorAddr: delegatorAddress, Pagination: pagination})
 		if err != nil {
-			consecutiveErrors++
-			if consecutiveErrors < errorThreshold {
-				<-time.After(1 * time.Second)
-				continue
-			}
 			return vals, err
 		}
-		consecutiveErrors = 0
 		total += uint64(len(vd.DelegationResponses))
 		for _, dr := range vd.DelegationResponses {
 			vals = append(vals,
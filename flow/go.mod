@@ -5,7 +5,8 @@ go 1.18
 require (
 	github.com/cosmos/cosmos-sdk v0.44.3
 	github.com/figment-networks/indexing-engine v0.9.21
-	github.com/figment-networks/ni-cosmoslib/client v0.1.1
+	github.com/figment-networks/ni-cosmoslib/client v0.2.0
+	github.com/figment-networks/ni-cosmoslib/util v0.2.0
 	github.com/tendermint/tendermint v0.34.14
 	go.uber.org/zap v1.17.0
 	golang.org/x/sync v0.0.0-20220601150217-0de741cfad7f
@@ -106,9 +107,13 @@ require (
 	google.golang.org/grpc v1.42.0 // indirect
 	gopkg.in/ini.v1 v1.62.0 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 replace google.golang.org/grpc => google.golang.org/grpc v1.33.2
 
 replace github.com/gogo/protobuf => github.com/regen-network/protobuf v1.3.3-alpha.regen.1
+
+replace github.com/figment-networks/ni-cosmoslib/util => ../util
+
+replace github.com/figment-networks/ni-cosmoslib/client => ../client
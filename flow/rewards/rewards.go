@@ -24,6 +24,7 @@ import (
 	pb "github.com/figment-networks/indexing-engine/proto/datastore"
 
 	"github.com/figment-networks/ni-cosmoslib/client/cosmosgrpc"
+	"github.com/figment-networks/ni-cosmoslib/util"
 
 	"google.golang.org/protobuf/proto"
 
@@ -77,6 +78,22 @@ type DelegatorValidator struct {
 	Amounts   []*rewstruct.Amount
 }
 
+// DelegationsFromTx returns delegation changes of the staking transactions. It can be used by
+// RewardProducer implementations in GetDelegations.
+func DelegationsFromTx(tx *rewstruct.RewardTx) (accounts []DelegatorValidator) {
+	// superfluid messages are skipped, the stake is delegated by the intermediary account of the lock, not by the sender
+	switch tx.Type {
+	// MsgCancelUnbondingDelegation re-delegates the unbonding amount
+	case "MsgDelegate", "MsgCancelUnbondingDelegation", "MsgRedeemTokensForShares":
+		accounts = append(accounts, DelegatorValidator{Op: DelegatorOPAdd, Delegator: tx.Delegator, Validator: tx.ValidatorDst, Amounts: tx.Amounts})
+	case "MsgUndelegate", "MsgTokenizeShares":
+		accounts = append(accounts, DelegatorValidator{Op: DelegatorOPRemove, Delegator: tx.Delegator, Validator: tx.ValidatorSrc, Amounts: tx.Amounts})
+	case "MsgBeginRedelegate":
+		accounts = append(accounts, DelegatorValidator{Op: DelegatorOPBoth, Delegator: tx.Delegator, Validator: tx.ValidatorDst, Amounts: tx.Amounts})
+	}
+	return accounts
+}
+
 type RewardProducer interface {
 	GetRewards(*rewstruct.RewardTx) []structs.ClaimedReward
 	GetDelegations(tx *rewstruct.RewardTx) (accounts []DelegatorValidator)
@@ -103,6 +120,18 @@ type RewardsExtractionConfig struct {
 	MaxChainHeight     uint64
 }
 
+// NewRewardsExtractionConfig creates config with the chain settings taken from the profile
+func NewRewardsExtractionConfig(p util.ChainProfile, datastorePrefix string, validatorFetchPage, delegatorFetchPage uint64) RewardsExtractionConfig {
+	return RewardsExtractionConfig{
+		ValidatorFetchPage: validatorFetchPage,
+		DelegatorFetchPage: delegatorFetchPage,
+		DatastorePrefix:    datastorePrefix,
+		ChainID:            p.ChainID,
+		Network:            p.Network,
+		MaxChainHeight:     p.MaxHeight,
+	}
+}
+
 type RewardsExtraction struct {
 	logger *zap.Logger
 	Cfg    RewardsExtractionConfig
@@ -853,11 +882,12 @@ func (re *RewardsExtraction) UnclaimedFetcher(ctx context.Context, height uint64
 		default:
 		}
 
+		// the client retries only the errors retryable by its retry policy, the others are repeated here
 		var consecutiveErrors int
 		del, err := re.client.GetDelegations(ctx, height, address)
 		if err != nil {
 		REPEATLOOP:
-			for {
+			for ctx.Err() == nil {
 				del, err = re.client.GetDelegations(ctx, height, address)
 				if err == nil {
 					break REPEATLOOP
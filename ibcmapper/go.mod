@@ -6,11 +6,13 @@ replace google.golang.org/grpc => google.golang.org/grpc v1.33.2
 
 replace github.com/gogo/protobuf => github.com/regen-network/protobuf v1.3.3-alpha.regen.1
 
+replace github.com/figment-networks/ni-cosmoslib/util => ../util
+
 require (
 	github.com/cosmos/cosmos-sdk v0.45.4
 	github.com/cosmos/ibc-go v1.4.0
 	github.com/figment-networks/indexing-engine v0.9.21
-	github.com/figment-networks/ni-cosmoslib/util v0.1.0
+	github.com/figment-networks/ni-cosmoslib/util v0.2.0
 	github.com/gogo/protobuf v1.3.3
 )
 
@@ -107,5 +109,5 @@ require (
 	google.golang.org/protobuf v1.27.1 // indirect
 	gopkg.in/ini.v1 v1.66.2 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,39 @@
+package ibcmapper
+
+import (
+	"fmt"
+
+	codec_types "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/figment-networks/indexing-engine/structs"
+	"github.com/figment-networks/ni-cosmoslib/util"
+)
+
+// IBCVersion is the major ibc-go version decoded by this package
+const IBCVersion = "v2"
+
+// Decodes checks if messages of the ibc-go version are decoded by this package
+func Decodes(version string) bool {
+	return version == "" || util.MajorVersion(version) == IBCVersion
+}
+
+// HeightRanges returns the ranges of heights of the upgrade schedule decoded by this package.
+// Versions of ibcmapper can't be built into the same binary (ibc-go versions register the same
+// errors and types), so the heights are routed to the binaries built with the matching versions.
+func HeightRanges(schedule util.UpgradeSchedule) []util.HeightRange {
+	if len(schedule) == 0 {
+		return []util.HeightRange{{}}
+	}
+	return schedule.HeightRanges(func(u util.Upgrade) bool { return Decodes(u.IBCVersion) })
+}
+
+// AddIBCSubEventAt is AddIBCSubEvent for the message of a transaction at the height. Heights of
+// other ibc-go versions in the upgrade schedule (outside of HeightRanges) are rejected with
+// util.ErrUnsupportedVersion.
+func AddIBCSubEventAt(tev *structs.TransactionEvent, m *codec_types.Any, lg types.ABCIMessageLog, height uint64, schedule util.UpgradeSchedule) (err error) {
+	if v := schedule.At(height).IBCVersion; !Decodes(v) {
+		err = fmt.Errorf("problem with ibc event %s at height %d (ibc-go %s): %w", m.TypeUrl, height, v, util.ErrUnsupportedVersion)
+		return util.WithMessage(err, "", "", m.TypeUrl, int(lg.MsgIndex))
+	}
+	return AddIBCSubEvent(tev, m, lg)
+}
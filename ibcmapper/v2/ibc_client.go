@@ -1,8 +1,6 @@
 package ibcmapper
 
 import (
-	"fmt"
-
 	"github.com/figment-networks/indexing-engine/structs"
 	shared "github.com/figment-networks/indexing-engine/structs"
 	"github.com/figment-networks/ni-cosmoslib/util"
@@ -15,7 +13,7 @@ import (
 func IBCCreateClientToSub(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &client.MsgCreateClient{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a create_client type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a create_client type: %w", err)
 	}
 
 	return shared.SubsetEvent{
@@ -35,7 +33,7 @@ func IBCCreateClientToSub(msg []byte) (se shared.SubsetEvent, err error) {
 func IBCUpdateClientToSub(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &client.MsgUpdateClient{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a update_client type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a update_client type: %w", err)
 	}
 
 	return shared.SubsetEvent{
@@ -55,7 +53,7 @@ func IBCUpdateClientToSub(msg []byte) (se shared.SubsetEvent, err error) {
 func IBCUpgradeClientToSub(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &client.MsgUpgradeClient{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a upgrade_client type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a upgrade_client type: %w", err)
 	}
 
 	// Encode fields that can contain null bytes.
@@ -88,7 +86,7 @@ func IBCUpgradeClientToSub(msg []byte) (se shared.SubsetEvent, err error) {
 func IBCSubmitMisbehaviourToSub(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &client.MsgSubmitMisbehaviour{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a submit_misbehaviour type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a submit_misbehaviour type: %w", err)
 	}
 
 	return shared.SubsetEvent{
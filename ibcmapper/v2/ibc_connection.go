@@ -15,7 +15,7 @@ import (
 func IBCConnectionOpenInitToSub(msg []byte) (se structs.SubsetEvent, err error) {
 	m := &connection.MsgConnectionOpenInit{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a connection_open_init type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a connection_open_init type: %w", err)
 	}
 
 	return structs.SubsetEvent{
@@ -40,7 +40,7 @@ func IBCConnectionOpenInitToSub(msg []byte) (se structs.SubsetEvent, err error)
 func IBCConnectionOpenConfirmToSub(msg []byte) (se structs.SubsetEvent, err error) {
 	m := &connection.MsgConnectionOpenConfirm{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a connection_open_confirm type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a connection_open_confirm type: %w", err)
 	}
 
 	// Encode fields that can contain null bytes.
@@ -68,7 +68,7 @@ func IBCConnectionOpenConfirmToSub(msg []byte) (se structs.SubsetEvent, err erro
 func IBCConnectionOpenAckToSub(msg []byte) (se structs.SubsetEvent, err error) {
 	m := &connection.MsgConnectionOpenAck{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a connection_open_ack type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a connection_open_ack type: %w", err)
 	}
 
 	// Encode fields that can contain null bytes.
@@ -112,7 +112,7 @@ func IBCConnectionOpenAckToSub(msg []byte) (se structs.SubsetEvent, err error) {
 func IBCConnectionOpenTryToSub(msg []byte) (se structs.SubsetEvent, err error) {
 	m := &connection.MsgConnectionOpenTry{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a connection_open_try type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a connection_open_try type: %w", err)
 	}
 
 	// Encode fields that can contain null bytes.
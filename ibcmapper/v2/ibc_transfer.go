@@ -1,7 +1,6 @@
 package ibcmapper
 
 import (
-	"fmt"
 	"strconv"
 
 	"github.com/figment-networks/indexing-engine/structs"
@@ -9,13 +8,15 @@ import (
 
 	transfer "github.com/cosmos/ibc-go/v2/modules/apps/transfer/types"
 	"github.com/gogo/protobuf/proto"
+
+	"github.com/figment-networks/ni-cosmoslib/util"
 )
 
 // IBCTransferToSub transforms ibc.MsgTransfer sdk messages to SubsetEvent
 func IBCTransferToSub(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &transfer.MsgTransfer{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a transfer type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a transfer type: %w", err)
 	}
 
 	amount := structs.TransactionAmount{
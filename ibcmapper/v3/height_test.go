@@ -0,0 +1,48 @@
+package ibcmapper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	codec_types "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/figment-networks/indexing-engine/structs"
+	"github.com/figment-networks/ni-cosmoslib/util"
+)
+
+func TestHeightRanges(t *testing.T) {
+	schedule := util.UpgradeSchedule{
+		{Height: 100, IBCVersion: "v1"},
+		{Height: 200, IBCVersion: "v2.0.0"},
+		{Height: 300, IBCVersion: "v3.0.0"},
+		{Height: 400, IBCVersion: "v4.2.0"},
+	}
+	tests := []struct {
+		name     string
+		schedule util.UpgradeSchedule
+		want     []util.HeightRange
+	}{
+		{name: "no_schedule", want: []util.HeightRange{{}}},
+		{name: "v3_and_later", schedule: schedule, want: []util.HeightRange{{From: 300}}},
+		{name: "v3_between", schedule: append(schedule[:3:3], util.Upgrade{Height: 400, IBCVersion: "v2"}, util.Upgrade{Height: 500, IBCVersion: "v3"}),
+			want: []util.HeightRange{{From: 300, To: 399}, {From: 500}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HeightRanges(tt.schedule); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("HeightRanges() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddIBCSubEventAt_UnsupportedVersion(t *testing.T) {
+	schedule := util.UpgradeSchedule{{Height: 100, IBCVersion: "v1"}, {Height: 200, IBCVersion: "v3.0.0"}}
+	for _, height := range []uint64{50, 150} {
+		err := AddIBCSubEventAt(&structs.TransactionEvent{}, &codec_types.Any{TypeUrl: "/ibc.core.channel.v1.MsgRecvPacket"}, types.ABCIMessageLog{}, height, schedule)
+		if !errors.Is(err, util.ErrUnsupportedVersion) {
+			t.Errorf("AddIBCSubEventAt() at %d error = %v, want ErrUnsupportedVersion", height, err)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package ibcmapper
 
 import (
 	"encoding/json"
-	"fmt"
 	"math/big"
 	"strconv"
 
@@ -27,7 +26,7 @@ var (
 func IBCChannelOpenInitToSub(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &channel.MsgChannelOpenInit{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a channel_open_init type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a channel_open_init type: %w", err)
 	}
 
 	return shared.SubsetEvent{
@@ -52,7 +51,7 @@ func IBCChannelOpenInitToSub(msg []byte) (se shared.SubsetEvent, err error) {
 func IBCChannelOpenConfirmToSub(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &channel.MsgChannelOpenConfirm{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a channel_open_confirm type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a channel_open_confirm type: %w", err)
 	}
 
 	// Encode fields that can contain null bytes.
@@ -81,7 +80,7 @@ func IBCChannelOpenConfirmToSub(msg []byte) (se shared.SubsetEvent, err error) {
 func IBCChannelOpenAckToSub(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &channel.MsgChannelOpenAck{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a channel_open_ack type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a channel_open_ack type: %w", err)
 	}
 
 	// Encode fields that can contain null bytes.
@@ -112,7 +111,7 @@ func IBCChannelOpenAckToSub(msg []byte) (se shared.SubsetEvent, err error) {
 func IBCChannelOpenTryToSub(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &channel.MsgChannelOpenTry{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a channel_open_try type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a channel_open_try type: %w", err)
 	}
 
 	// Encode fields that can contain null bytes.
@@ -148,7 +147,7 @@ func IBCChannelOpenTryToSub(msg []byte) (se shared.SubsetEvent, err error) {
 func IBCChannelCloseInitToSub(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &channel.MsgChannelCloseInit{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a channel_close_init type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a channel_close_init type: %w", err)
 	}
 
 	return shared.SubsetEvent{
@@ -168,7 +167,7 @@ func IBCChannelCloseInitToSub(msg []byte) (se shared.SubsetEvent, err error) {
 func IBCChannelCloseConfirmToSub(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &channel.MsgChannelCloseConfirm{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a channel_close_confirm type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a channel_close_confirm type: %w", err)
 	}
 
 	// Encode fields that can contain null bytes.
@@ -197,7 +196,7 @@ func IBCChannelCloseConfirmToSub(msg []byte) (se shared.SubsetEvent, err error)
 func IBCChannelRecvPacketToSub(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &channel.MsgRecvPacket{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a recv_packet type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a recv_packet type: %w", err)
 	}
 
 	// Encode fields that can contain null bytes.
@@ -235,7 +234,7 @@ func IBCChannelRecvPacketToSub(msg []byte) (se shared.SubsetEvent, err error) {
 func IBCChannelTimeoutToSub(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &channel.MsgTimeout{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a timeout type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a timeout type: %w", err)
 	}
 
 	// Encode fields that can contain null bytes.
@@ -274,7 +273,7 @@ func IBCChannelTimeoutToSub(msg []byte) (se shared.SubsetEvent, err error) {
 func IBCChannelTimeoutOnCloseToSub(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &channel.MsgTimeoutOnClose{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf(invalidTypeErrFmt, constChannelTimeoutOnClose, err)
+		return se, util.Errorf(util.ErrMalformedMessage, invalidTypeErrFmt, constChannelTimeoutOnClose, err)
 	}
 
 	// Encode fields that can contain null bytes.
@@ -319,7 +318,7 @@ func IBCChannelTimeoutOnCloseToSub(msg []byte) (se shared.SubsetEvent, err error
 func IBCChannelAcknowledgementToSub(msg []byte) (se shared.SubsetEvent, err error) {
 	m := &channel.MsgAcknowledgement{}
 	if err := proto.Unmarshal(msg, m); err != nil {
-		return se, fmt.Errorf("Not a channel_acknowledgement type: %w", err)
+		return se, util.Errorf(util.ErrMalformedMessage, "Not a channel_acknowledgement type: %w", err)
 	}
 
 	// Encode fields that can contain null bytes.
@@ -358,14 +357,14 @@ func ParsePacket(data []byte, event *structs.SubsetEvent) error {
 	var packetData *types.FungibleTokenPacketData
 	err := json.Unmarshal(data, &packetData)
 	if err != nil {
-		return fmt.Errorf("packet malformed: %w", err)
+		return util.Errorf(util.ErrMalformedMessage, "packet malformed: %w", err)
 	}
 	amt, ok := new(big.Int).SetString(packetData.Amount, 10)
 	if !ok {
-		return fmt.Errorf("packet amount not a string: %v", packetData)
+		return util.Errorf(util.ErrAmountParse, "packet amount not a string: %v", packetData)
 	}
 	if amt.Cmp(bigZero) < 0 || len(packetData.Denom) == 0 || len(packetData.Sender) == 0 || len(packetData.Receiver) == 0 {
-		return fmt.Errorf("packet malformed: %v", packetData)
+		return util.Errorf(util.ErrMalformedMessage, "packet malformed: %v", packetData)
 	}
 	// adding the Amount on the receiver.
 	event.Sender = []structs.EventTransfer{
@@ -19,7 +19,7 @@ func AddIBCSubEvent(tev *structs.TransactionEvent, m *codec_types.Any, lg types.
 	// TypeUrl must be in the format "/ibc.core.client.v1.MsgCreateClient"
 	tPath := strings.Split(m.TypeUrl, ".")
 	if len(tPath) != 5 {
-		return fmt.Errorf("problem with ibc event ibc event %s: %w", m.TypeUrl, util.ErrUnknownMessageType)
+		return util.WithMessage(fmt.Errorf("problem with ibc event ibc event %s: %w", m.TypeUrl, util.ErrUnknownMessageType), "", "", m.TypeUrl, int(lg.MsgIndex))
 	}
 
 	msgType := tPath[4]
@@ -95,5 +95,5 @@ func AddIBCSubEvent(tev *structs.TransactionEvent, m *codec_types.Any, lg types.
 		tev.Kind = ev.Type[0]
 	}
 
-	return err
+	return util.WithMessage(err, msgRoute, msgType, m.TypeUrl, int(lg.MsgIndex))
 }
@@ -0,0 +1,149 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ChainProfile is the chain specific configuration shared by the api mappers and the rewards flow
+type ChainProfile struct {
+	Name    string `json:"name" yaml:"name"`
+	ChainID string `json:"chain_id" yaml:"chain_id"`
+	Network string `json:"network" yaml:"network"`
+
+	Bech32         Bech32Prefixes `json:"bech32" yaml:"bech32"`
+	StakingDenom   string         `json:"staking_denom" yaml:"staking_denom"`
+	ModuleAccounts ModuleAccounts `json:"module_accounts" yaml:"module_accounts"`
+
+	// Upgrades are the chain upgrades changing message and event formats
+	Upgrades UpgradeSchedule `json:"upgrades" yaml:"upgrades"`
+	// HaltHeights are heights the chain was halted at for upgrades
+	HaltHeights []uint64 `json:"halt_heights" yaml:"halt_heights"`
+	// MaxHeight is the last height of the chain id, 0 for a running chain
+	MaxHeight uint64 `json:"max_height" yaml:"max_height"`
+
+	// Modules are chain specific module mappers to enable (ie "osmosis", "liquidity")
+	Modules []string `json:"modules" yaml:"modules"`
+}
+
+// Bech32Prefixes are bech32 prefixes of the chain addresses
+type Bech32Prefixes struct {
+	Account   string `json:"account" yaml:"account"`
+	Validator string `json:"validator" yaml:"validator"`
+	Consensus string `json:"consensus" yaml:"consensus"`
+}
+
+// ModuleAccounts are names of the module accounts used by the mappers
+type ModuleAccounts struct {
+	BondedPool    string `json:"bonded_pool" yaml:"bonded_pool"`
+	NotBondedPool string `json:"not_bonded_pool" yaml:"not_bonded_pool"`
+}
+
+// BuiltinChainProfiles are profiles of the supported chains. Upgrades list the genesis of the chain id
+// and the upgrades changing the cosmos-sdk minor or the ibc-go major version known at the release,
+// newer ones are added in profiles loaded with LoadChainProfiles.
+var BuiltinChainProfiles = map[string]ChainProfile{
+	"cosmos": {
+		Name: "cosmos", ChainID: "cosmoshub-4", Network: "mainnet",
+		Bech32: Bech32Prefixes{Account: "cosmos"}, StakingDenom: "uatom",
+		Upgrades: UpgradeSchedule{
+			{Height: 5200791, Name: "cosmoshub-4", SDKVersion: "v0.41.0", IBCVersion: "v1"},
+			{Height: 6910000, Name: "v5-gravity-dex", SDKVersion: "v0.42.6", IBCVersion: "v1"},
+			{Height: 8695000, Name: "v6-vega", SDKVersion: "v0.44.3", IBCVersion: "v2.0.0"},
+			{Height: 10085397, Name: "v7-theta", SDKVersion: "v0.45.1", IBCVersion: "v3.0.0"},
+			{Height: 14470501, Name: "v9-lambda", SDKVersion: "v0.45.14-ics", IBCVersion: "v4.2.0"},
+			{Height: 19939000, Name: "v15", SDKVersion: "v0.47.10", IBCVersion: "v7.3.1"},
+		},
+	},
+	"osmosis": {
+		Name: "osmosis", ChainID: "osmosis-1", Network: "mainnet",
+		Bech32: Bech32Prefixes{Account: "osmo"}, StakingDenom: "uosmo",
+		Upgrades: UpgradeSchedule{
+			{Height: 1, Name: "osmosis-1", SDKVersion: "v0.42.4", IBCVersion: "v1"},
+			{Height: 2383300, Name: "v5-boron", SDKVersion: "v0.44.3", IBCVersion: "v2.0.0"},
+			{Height: 3401000, Name: "v7-carbon", SDKVersion: "v0.45.0", IBCVersion: "v2.0.2"},
+			{Height: 4707300, Name: "v9-nitrogen", SDKVersion: "v0.45.0", IBCVersion: "v3.0.0"},
+			{Height: 8732500, Name: "v15", SDKVersion: "v0.45.0", IBCVersion: "v4.3.0"},
+			{Height: 12028900, Name: "v20", SDKVersion: "v0.47.5", IBCVersion: "v7.3.1"},
+		},
+		Modules: []string{"osmosis"},
+	},
+	"kava": {
+		Name: "kava", ChainID: "kava_2222-10", Network: "mainnet",
+		Bech32: Bech32Prefixes{Account: "kava"}, StakingDenom: "ukava",
+		Upgrades: UpgradeSchedule{
+			{Height: 1, Name: "kava_2222-10", SDKVersion: "v0.45.4", IBCVersion: "v3.0.0"},
+			{Height: 5597000, Name: "v0.24", SDKVersion: "v0.46.11", IBCVersion: "v6.1.0"},
+		},
+	},
+	"juno": {
+		Name: "juno", ChainID: "juno-1", Network: "mainnet",
+		Bech32: Bech32Prefixes{Account: "juno"}, StakingDenom: "ujuno",
+		Upgrades: UpgradeSchedule{
+			{Height: 1, Name: "juno-1", SDKVersion: "v0.44.0", IBCVersion: "v1.2.0"},
+			{Height: 2578099, Name: "v9-lupercalia", SDKVersion: "v0.45.1", IBCVersion: "v3.0.0"},
+		},
+	},
+}
+
+// BuiltinChainProfile returns the built-in profile (ie "osmosis") with defaults set
+func BuiltinChainProfile(name string) (p ChainProfile, err error) {
+	p, ok := BuiltinChainProfiles[name]
+	if !ok {
+		return p, fmt.Errorf("unknown chain profile: %s", name)
+	}
+	return p.WithDefaults(), nil
+}
+
+// LoadChainProfiles reads a list of profiles in YAML or JSON format
+func LoadChainProfiles(r io.Reader) (profiles []ChainProfile, err error) {
+	if err := yaml.NewDecoder(r).Decode(&profiles); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("error decoding chain profiles: %w", err)
+	}
+	for i, p := range profiles {
+		profiles[i] = p.WithDefaults()
+	}
+	return profiles, nil
+}
+
+// LoadChainProfilesFile reads a list of profiles from YAML or JSON file
+func LoadChainProfilesFile(path string) ([]ChainProfile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadChainProfiles(f)
+}
+
+// WithDefaults returns the profile with the cosmos-sdk defaults set for empty prefixes and module accounts
+// and with upgrades sorted by height
+func (p ChainProfile) WithDefaults() ChainProfile {
+	if p.Bech32.Validator == "" && p.Bech32.Account != "" {
+		p.Bech32.Validator = p.Bech32.Account + "valoper"
+	}
+	if p.Bech32.Consensus == "" && p.Bech32.Account != "" {
+		p.Bech32.Consensus = p.Bech32.Account + "valcons"
+	}
+	if p.ModuleAccounts.BondedPool == "" {
+		p.ModuleAccounts.BondedPool = "bonded_tokens_pool"
+	}
+	if p.ModuleAccounts.NotBondedPool == "" {
+		p.ModuleAccounts.NotBondedPool = "not_bonded_tokens_pool"
+	}
+	p.Upgrades = p.Upgrades.Sorted()
+	return p
+}
+
+// HasModule checks if the chain specific module mapper is enabled
+func (p ChainProfile) HasModule(module string) bool {
+	for _, m := range p.Modules {
+		if m == module {
+			return true
+		}
+	}
+	return false
+}
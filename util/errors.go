@@ -1,5 +1,105 @@
 package util
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var ErrUnknownMessageType = fmt.Errorf("unknown message type")
+
+var ErrUnsupportedVersion = fmt.Errorf("unsupported version")
+
+// ErrMalformedMessage is the kind of errors of decoding messages and packets
+var ErrMalformedMessage = fmt.Errorf("malformed message")
+
+// ErrMissingEvent is the kind of errors of expected events missing in the message log
+var ErrMissingEvent = fmt.Errorf("missing event")
+
+// ErrAmountParse is the kind of errors of parsing amounts of messages and events
+var ErrAmountParse = fmt.Errorf("amount parse error")
+
+var errorKinds = []error{ErrUnknownMessageType, ErrMalformedMessage, ErrMissingEvent, ErrAmountParse, ErrUnsupportedVersion}
+
+// MappingError is an error of mapping the message of a transaction. Kind is one of
+// ErrUnknownMessageType, ErrMalformedMessage, ErrMissingEvent, ErrAmountParse or ErrUnsupportedVersion,
+// so the errors can be checked with errors.Is(err, util.ErrMissingEvent) and the message
+// details are available with errors.As.
+type MappingError struct {
+	Kind error
+
+	Module   string
+	MsgType  string
+	TypeURL  string
+	MsgIndex int
+
+	Err error
+}
+
+// Errorf creates MappingError of the kind formatting the error like fmt.Errorf
+func Errorf(kind error, format string, a ...interface{}) error {
+	return &MappingError{Kind: kind, MsgIndex: -1, Err: fmt.Errorf(format, a...)}
+}
+
+// WithMessage adds the message details to the error, wrapping errors other than MappingError.
+// Details already set (ie by a mapper of a nested message) are kept.
+func WithMessage(err error, module, msgType, typeURL string, msgIndex int) error {
+	if err == nil {
+		return nil
+	}
+
+	var me *MappingError
+	if !errors.As(err, &me) {
+		me = &MappingError{MsgIndex: -1, Err: err}
+		for _, kind := range errorKinds {
+			if errors.Is(err, kind) {
+				me.Kind = kind
+				break
+			}
+		}
+		err = me
+	}
+
+	if me.Module == "" {
+		me.Module = module
+	}
+	if me.MsgType == "" {
+		me.MsgType = msgType
+	}
+	if me.TypeURL == "" {
+		me.TypeURL = typeURL
+	}
+	if me.MsgIndex < 0 {
+		me.MsgIndex = msgIndex
+	}
+	return err
+}
+
+func (e *MappingError) Error() string {
+	var details []string
+	if e.Module != "" {
+		details = append(details, "module: "+e.Module)
+	}
+	if e.MsgType != "" {
+		details = append(details, "msg type: "+e.MsgType)
+	}
+	if e.TypeURL != "" {
+		details = append(details, "type url: "+e.TypeURL)
+	}
+	if e.MsgIndex >= 0 {
+		details = append(details, fmt.Sprintf("msg index: %d", e.MsgIndex))
+	}
+	if len(details) == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s (%s)", e.Err.Error(), strings.Join(details, ", "))
+}
+
+func (e *MappingError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is the kind of the error
+func (e *MappingError) Is(target error) bool {
+	return e.Kind != nil && target == e.Kind
+}
@@ -0,0 +1,121 @@
+package util
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Upgrade is a chain upgrade changing message and event formats from the height
+type Upgrade struct {
+	Height uint64 `json:"height" yaml:"height"`
+	Name   string `json:"name" yaml:"name"`
+	// SDKVersion is the cosmos-sdk version (ie "v0.46")
+	SDKVersion string `json:"sdk_version" yaml:"sdk_version"`
+	// IBCVersion is the ibc-go version (ie "v3"), empty when ibc-go is not used
+	IBCVersion string `json:"ibc_version" yaml:"ibc_version"`
+}
+
+// SDKAtLeast checks if the cosmos-sdk version of the upgrade is at least the given one,
+// unknown version is considered to be the latest one
+func (u Upgrade) SDKAtLeast(version string) bool {
+	return u.SDKVersion == "" || CompareVersions(u.SDKVersion, version) >= 0
+}
+
+// UpgradeSchedule is the list of chain upgrades sorted by height
+type UpgradeSchedule []Upgrade
+
+// Sorted returns a copy of the schedule sorted by height
+func (s UpgradeSchedule) Sorted() UpgradeSchedule {
+	sorted := append(UpgradeSchedule(nil), s...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Height < sorted[j].Height })
+	return sorted
+}
+
+// At returns the upgrade in effect at the height. The first upgrade is the genesis of the chain id
+// and applies from height 0, versions are empty (meaning the latest ones) only for an empty schedule.
+func (s UpgradeSchedule) At(height uint64) (u Upgrade) {
+	for i, up := range s {
+		if i > 0 && up.Height > height {
+			break
+		}
+		u = up
+	}
+	return u
+}
+
+// HeightRange is the range of heights, To is 0 for an open range
+type HeightRange struct {
+	From uint64
+	To   uint64
+}
+
+// HeightRanges returns the ranges of heights of upgrades matching the filter, the first upgrade
+// applies from height 0. It is used to split indexing of the chain between versions of mappers
+// which can't be built into the same binary (ie ibcmapper).
+func (s UpgradeSchedule) HeightRanges(filter func(u Upgrade) bool) (ranges []HeightRange) {
+	for i, up := range s {
+		if !filter(up) {
+			continue
+		}
+		from := up.Height
+		if i == 0 {
+			from = 0
+		}
+		var to uint64
+		if i+1 < len(s) {
+			to = s[i+1].Height - 1
+		}
+		if l := len(ranges); l > 0 && ranges[l-1].To+1 == from {
+			ranges[l-1].To = to
+			continue
+		}
+		ranges = append(ranges, HeightRange{From: from, To: to})
+	}
+	return ranges
+}
+
+// CompareVersions compares versions in the "v<major>.<minor>.<patch>" format, missing parts are
+// considered to be 0 and suffixes (ie "-ics") are ignored. Result is -1, 0 or 1 like in strings.Compare.
+func CompareVersions(a, b string) int {
+	ap, bp := versionParts(a), versionParts(b)
+	for i := 0; i < len(ap) || i < len(bp); i++ {
+		var av, bv uint64
+		if i < len(ap) {
+			av = ap[i]
+		}
+		if i < len(bp) {
+			bv = bp[i]
+		}
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(v string) (parts []uint64) {
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	for _, p := range strings.Split(v, ".") {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// MajorVersion returns the major part of the version (ie "v3" for "v3.4.0")
+func MajorVersion(v string) string {
+	if i := strings.Index(v, "."); i >= 0 {
+		return v[:i]
+	}
+	return v
+}